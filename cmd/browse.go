@@ -5,12 +5,12 @@ import (
 	"os"
 	"os/exec"
 	"strconv"
-	"strings"
 
 	"github.com/fatih/color"
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 	"github.com/tinsfox/gwt/internal/git"
+	"github.com/tinsfox/gwt/internal/i18n"
 )
 
 // browseCmd 交互式浏览 worktree
@@ -41,17 +41,17 @@ func runBrowse(cmd *cobra.Command, args []string) error {
 	// 检查是否在 git 仓库中
 	repo, err := git.OpenRepository(".")
 	if err != nil {
-		return fmt.Errorf("不是 Git 仓库: %w", err)
+		return err
 	}
 
 	// 获取所有 worktree
 	worktrees, err := repo.GetWorktrees()
 	if err != nil {
-		return fmt.Errorf("获取 worktree 列表失败: %w", err)
+		return i18n.Errorf("获取 worktree 列表失败: %w", err)
 	}
 
 	if len(worktrees) == 0 {
-		fmt.Println("当前仓库没有 worktree")
+		fmt.Println(i18n.T("当前仓库没有 worktree"))
 		return nil
 	}
 
@@ -68,7 +68,7 @@ func runBrowse(cmd *cobra.Command, args []string) error {
 	selectedWorktree := worktrees[selectedIndex]
 
 	if !quiet {
-		fmt.Printf("选择: %s (%s)\n", selectedWorktree.Branch, selectedWorktree.Path)
+		i18n.Printf("选择: %s (%s)\n", selectedWorktree.Branch, selectedWorktree.Path)
 	}
 
 	// 根据选项执行操作
@@ -84,12 +84,12 @@ func runBrowse(cmd *cobra.Command, args []string) error {
 // showInteractiveTable 显示交互式表格
 func showInteractiveTable(worktrees []git.WorktreeInfo) (int, error) {
 	fmt.Println()
-	fmt.Println("选择要打开的 worktree (输入数字，按 Enter 确认，按 q 退出):")
+	fmt.Println(i18n.T("选择要打开的 worktree (输入数字，按 Enter 确认，按 q 退出):"))
 	fmt.Println()
 
 	// 创建表格
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"编号", "分支", "路径", "状态"})
+	table.SetHeader([]string{i18n.T("编号"), i18n.T("分支"), i18n.T("路径"), i18n.T("状态")})
 
 	// 设置样式
 	table.SetBorder(true)
@@ -110,13 +110,10 @@ func showInteractiveTable(worktrees []git.WorktreeInfo) (int, error) {
 	table.Render()
 
 	fmt.Println()
-	fmt.Print("输入编号: ")
-
-	var input string
-	fmt.Scanln(&input)
-
-	// 处理输入
-	input = strings.TrimSpace(input)
+	input, err := prompter.Input(i18n.T("输入编号"), "")
+	if err != nil {
+		return -1, err
+	}
 
 	if input == "q" || input == "quit" || input == "exit" {
 		return -1, nil
@@ -125,12 +122,12 @@ func showInteractiveTable(worktrees []git.WorktreeInfo) (int, error) {
 	// 解析数字
 	var index int
 	if _, err := fmt.Sscanf(input, "%d", &index); err != nil {
-		return -1, fmt.Errorf("无效的输入: %s", input)
+		return -1, i18n.Errorf("无效的输入: %s", input)
 	}
 
 	// 验证范围
 	if index < 1 || index > len(worktrees) {
-		return -1, fmt.Errorf("编号超出范围: %d (有效范围: 1-%d)", index, len(worktrees))
+		return -1, i18n.Errorf("编号超出范围: %d (有效范围: 1-%d)", index, len(worktrees))
 	}
 
 	return index - 1, nil
@@ -139,24 +136,24 @@ func showInteractiveTable(worktrees []git.WorktreeInfo) (int, error) {
 // getWorktreeStatusBrowse 获取 worktree 状态显示
 func getWorktreeStatusBrowse(wt *git.WorktreeInfo) string {
 	if wt.IsLocked {
-		return color.YellowString("已锁定")
+		return color.YellowString(i18n.T("已锁定"))
 	}
 
 	if wt.IsDirty {
-		return color.RedString("已修改")
+		return color.RedString(i18n.T("已修改"))
 	}
 
 	if wt.IsMain {
-		return color.GreenString("主工作区")
+		return color.GreenString(i18n.T("主工作区"))
 	}
 
-	return color.GreenString("清洁")
+	return color.GreenString(i18n.T("清洁"))
 }
 
 // openWithEditor 使用编辑器打开目录
 func openWithEditor(path string) error {
 	// 这里简化处理，实际应该调用 edit 命令的逻辑
-	fmt.Printf("使用默认编辑器打开: %s\n", path)
+	i18n.Printf("使用默认编辑器打开: %s\n", path)
 
 	// 获取默认编辑器
 	editor := os.Getenv("EDITOR")
@@ -174,7 +171,7 @@ func openWithEditor(path string) error {
 
 // changeDirectoryBrowse 切换目录
 func changeDirectoryBrowse(path string) error {
-	fmt.Printf("切换到目录: %s\n", path)
+	i18n.Printf("切换到目录: %s\n", path)
 
 	// 获取当前 shell
 	shell := os.Getenv("SHELL")
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tinsfox/gwt/internal/i18n"
+	"github.com/tinsfox/gwt/pkg/gwt"
+)
+
+var (
+	carryStaged   bool
+	carryMove     bool
+	carryThreeWay bool
+)
+
+// carryCmd 在 worktree 之间转移未提交的修改
+var carryCmd = &cobra.Command{
+	Use:   "carry <from> <to> [path...]",
+	Short: "把未提交的修改转移到另一个 worktree",
+	Long: `把 from 中未提交的修改（包括未跟踪文件）应用到 to，适合在错误的 worktree 中开始修改之后使用。
+
+修改以补丁的形式一次性应用：有冲突时列出冲突的文件，目标 worktree 保持不变。
+默认保留源 worktree 中的修改；使用 --move 时应用成功后从源 worktree 中移除，
+如果无法移除，目标 worktree 中已应用的修改会被撤销。
+
+指定路径时只转移这些路径（相对于 worktree 根目录）的修改。`,
+	Example: `  # 把 main 中的修改复制到 feature/login
+  gwt carry main feature/login
+
+  # 只转移暂存的修改，并从 main 中移除
+  gwt carry main feature/login --staged --move
+
+  # 只转移 src 目录，有冲突时写入冲突标记
+  gwt carry main feature/login src/ --3way`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runCarry,
+}
+
+func init() {
+	rootCmd.AddCommand(carryCmd)
+
+	carryCmd.Flags().BoolVarP(&carryStaged, "staged", "s", false, "只转移暂存区中的修改")
+	carryCmd.Flags().BoolVarP(&carryMove, "move", "m", false, "应用成功后从源 worktree 中移除修改")
+	carryCmd.Flags().BoolVarP(&carryThreeWay, "3way", "3", false, "无法直接应用时尝试三方合并，冲突以冲突标记写入目标 worktree")
+}
+
+func runCarry(cmd *cobra.Command, args []string) error {
+	result, err := newClient(cmd).Carry(cmd.Context(), args[0], args[1], gwt.CarryOptions{
+		Paths:      args[2:],
+		StagedOnly: carryStaged,
+		Move:       carryMove,
+		ThreeWay:   carryThreeWay,
+	})
+
+	var conflict *gwt.ApplyConflictError
+	if errors.As(err, &conflict) && conflict.Merged {
+		fmt.Printf("⚠️  %s\n", color.YellowString(i18n.T("修改已应用到 %s，以下文件有冲突:", result.To.Path)))
+		for _, file := range conflict.Files {
+			fmt.Printf("  %s %s\n", color.RedString("U"), file)
+		}
+		if carryMove {
+			i18n.Printf("源 worktree 中的修改已保留\n")
+		}
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	if quiet {
+		return nil
+	}
+
+	i18n.Printf("✓ 已将 %d 个文件的修改从 %s 转移到 %s\n", len(result.Files),
+		color.CyanString(describeWorktree(result.From)), color.CyanString(describeWorktree(result.To)))
+	for _, file := range result.Files {
+		fmt.Printf("  %s\n", file)
+	}
+	if result.Moved {
+		i18n.Printf("源 worktree 中的这些修改已移除\n")
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tinsfox/gwt/internal/i18n"
+	"github.com/tinsfox/gwt/pkg/gwt"
+)
+
+var (
+	cleanDryRun bool
+	cleanForce  bool
+)
+
+// cleanCmd 删除临时 worktree
+var cleanCmd = &cobra.Command{
+	Use:   "clean",
+	Short: "删除 gwt scratch 创建的临时 worktree",
+	Long: `删除所有由 gwt scratch 创建的临时 worktree。
+
+有未保存工作的临时 worktree 会被跳过，使用 --force 时先备份到回收站再删除。
+锁定的临时 worktree 始终保留。`,
+	Example: `  # 删除所有临时 worktree
+  gwt clean
+
+  # 预览要删除的临时 worktree
+  gwt clean --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: runClean,
+}
+
+func init() {
+	rootCmd.AddCommand(cleanCmd)
+
+	cleanCmd.Flags().BoolVar(&cleanDryRun, "dry-run", false, "预览要删除的临时 worktree，不实际执行")
+	cleanCmd.Flags().BoolVarP(&cleanForce, "force", "f", false, "删除有未保存工作的临时 worktree（删除前备份到回收站）")
+}
+
+func runClean(cmd *cobra.Command, args []string) error {
+	client := newClient(cmd)
+
+	worktrees, err := client.List(cmd.Context())
+	if err != nil {
+		return i18n.Errorf("获取 worktree 列表失败: %w", err)
+	}
+
+	var targets []gwt.Worktree
+	for _, wt := range worktrees {
+		if wt.IsScratch && !wt.IsMain {
+			targets = append(targets, wt)
+		}
+	}
+
+	if len(targets) == 0 {
+		if !quiet {
+			fmt.Println(i18n.T("没有临时 worktree"))
+		}
+		return nil
+	}
+
+	if !quiet {
+		i18n.Printf("发现 %d 个临时 worktree:\n", len(targets))
+		for _, wt := range targets {
+			fmt.Printf("  %s (%s)\n", color.YellowString(wt.Path), color.CyanString(describeWorktreeRef(wt)))
+		}
+	}
+
+	if cleanDryRun {
+		if !quiet {
+			fmt.Println(i18n.T("\n这是预览模式，没有实际执行删除操作。"))
+		}
+		return nil
+	}
+
+	if !quiet {
+		fmt.Println()
+	}
+
+	ok, err := prompter.Confirm(i18n.T("确认删除这些临时 worktree?"), false)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return i18n.Errorf("取消删除")
+	}
+
+	var removed int
+	var unsaved bool
+	for _, wt := range targets {
+		result, err := client.Remove(cmd.Context(), wt.Path, gwt.RemoveOptions{Force: cleanForce})
+		if err != nil {
+			unsaved = unsaved || errors.Is(err, gwt.ErrUnsavedWork)
+			logWarning(i18n.T("跳过 %s: %v", wt.Path, err))
+			continue
+		}
+		printRemoveResult(result)
+		removed++
+	}
+
+	if !quiet {
+		fmt.Printf("✅ %s\n", color.GreenString(i18n.T("已删除 %d/%d 个临时 worktree", removed, len(targets))))
+	}
+	if unsaved {
+		fmt.Printf("💡 %s\n", color.BlueString(i18n.T("使用 gwt clean --force 删除有未保存工作的临时 worktree（会先备份到回收站）")))
+	}
+
+	return nil
+}
+
+// describeWorktreeRef 返回 worktree 检出的分支，分离 HEAD 时返回提交的描述
+func describeWorktreeRef(wt gwt.Worktree) string {
+	if wt.Branch != "" {
+		return wt.Branch
+	}
+	return wt.Describe
+}
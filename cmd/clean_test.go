@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/tinsfox/gwt/internal/git"
+)
+
+// setupTestRepo 创建带有一个提交的仓库并切换到其中，测试结束后恢复工作目录
+//
+// HOME 指向临时目录，不读取用户的 gwt 和 git 配置。
+func setupTestRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("HOME", root)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_AUTHOR_NAME", "gwt")
+	t.Setenv("GIT_AUTHOR_EMAIL", "gwt@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "gwt")
+	t.Setenv("GIT_COMMITTER_EMAIL", "gwt@example.com")
+
+	repo := filepath.Join(root, "repo")
+	if err := os.Mkdir(repo, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "--quiet", "--initial-branch=main"},
+		{"commit", "--quiet", "--allow-empty", "-m", "init"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, output)
+		}
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(repo); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	return repo
+}
+
+// runGwt 执行 gwt 命令，结束后把修改过的选项恢复为默认值
+func runGwt(t *testing.T, args ...string) error {
+	t.Helper()
+	prompter = nil
+	defer func() {
+		prompter = nil
+		resetFlags(rootCmd)
+	}()
+
+	rootCmd.SetArgs(args)
+	return rootCmd.ExecuteContext(context.Background())
+}
+
+// resetFlags 把 cmd 及其子命令中修改过的选项恢复为默认值
+func resetFlags(cmd *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if !f.Changed {
+			return
+		}
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			slice.Replace(nil)
+		} else {
+			f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	}
+	cmd.Flags().VisitAll(reset)
+	cmd.PersistentFlags().VisitAll(reset)
+	for _, sub := range cmd.Commands() {
+		resetFlags(sub)
+	}
+}
+
+func TestCleanForceBacksUpEveryScratchWorktree(t *testing.T) {
+	repoPath := setupTestRepo(t)
+
+	repo, err := git.OpenRepository(repoPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 连续创建的临时 worktree 通常在同一秒内，回收站条目的时间部分相同
+	const count = 3
+	for i := 0; i < count; i++ {
+		if err := runGwt(t, "scratch", "-q"); err != nil {
+			t.Fatalf("gwt scratch: %v", err)
+		}
+	}
+
+	worktrees, err := repo.GetWorktrees()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var scratches []string
+	for _, wt := range worktrees {
+		if wt.IsScratch {
+			scratches = append(scratches, wt.Path)
+			if err := os.WriteFile(filepath.Join(wt.Path, "notes.txt"), []byte(wt.Path), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if len(scratches) != count {
+		t.Fatalf("created %d scratch worktrees, want %d", len(scratches), count)
+	}
+
+	if err := runGwt(t, "clean", "--force", "--yes", "-q"); err != nil {
+		t.Fatalf("gwt clean --force: %v", err)
+	}
+
+	entries, err := repo.TrashEntries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != count {
+		t.Fatalf("trash has %d entries, want %d", len(entries), count)
+	}
+
+	for _, entry := range entries {
+		if _, err := repo.RestoreFromTrash(entry, ""); err != nil {
+			t.Fatalf("restore %s: %v", entry.ID, err)
+		}
+		data, err := os.ReadFile(filepath.Join(entry.Path, "notes.txt"))
+		if err != nil || string(data) != entry.Path {
+			t.Errorf("restored %s/notes.txt = %q, %v, want %q", entry.Path, data, err, entry.Path)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tinsfox/gwt/internal/git"
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+// cloneCmd 以 worktree-per-branch 布局克隆仓库
+var cloneCmd = &cobra.Command{
+	Use:   "clone <url> [dir]",
+	Short: "以裸仓库 + 每分支一个 worktree 的布局克隆仓库",
+	Long: `克隆仓库为 worktree-per-branch 布局：
+
+  <dir>/
+    .bare/   裸仓库
+    .git     指向 .bare 的 gitfile
+    main/    默认分支的 worktree
+
+之后在 <dir> 中运行 gwt create <branch>，新的 worktree 会与 main/ 并列创建。`,
+	Example: `  # 克隆到 ./project
+  gwt clone git@github.com:org/project.git
+
+  # 克隆到指定目录
+  gwt clone https://github.com/org/project.git ~/src/project`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runClone,
+}
+
+func init() {
+	rootCmd.AddCommand(cloneCmd)
+}
+
+func runClone(cmd *cobra.Command, args []string) error {
+	url := args[0]
+
+	dir := ""
+	if len(args) > 1 {
+		dir = args[1]
+	} else {
+		dir = repoNameFromURL(url)
+	}
+
+	if !quiet {
+		i18n.Printf("克隆仓库:\n")
+		i18n.Printf("  地址: %s\n", color.CyanString(url))
+		i18n.Printf("  目录: %s\n", color.YellowString(dir))
+	}
+
+	repo, defaultBranch, err := git.CloneBare(cmd.Context(), nil, url, dir)
+	if err != nil {
+		return err
+	}
+
+	if !quiet {
+		fmt.Println()
+		fmt.Printf("✅ %s\n", color.GreenString(i18n.T("克隆成功！")))
+		i18n.Printf("   裸仓库: %s\n", repo.CommonDir)
+		i18n.Printf("   默认分支: %s\n", color.CyanString(defaultBranch))
+		fmt.Println()
+		fmt.Printf("💡 %s\n", color.BlueString(i18n.T("提示:")))
+		i18n.Printf("   cd %s/%s    # 进入默认分支的 worktree\n", dir, defaultBranch)
+		i18n.Printf("   gwt create feature/x  # 在 %s 下创建新的 worktree\n", dir)
+	}
+
+	return nil
+}
+
+// repoNameFromURL 从仓库地址推断目录名
+func repoNameFromURL(url string) string {
+	url = strings.TrimRight(url, "/")
+	// 兼容 git@host:org/repo.git 形式
+	if i := strings.LastIndex(url, ":"); i >= 0 && !strings.Contains(url[i:], "/") {
+		url = url[i+1:]
+	}
+	return strings.TrimSuffix(path.Base(url), ".git")
+}
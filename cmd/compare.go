@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tinsfox/gwt/internal/i18n"
+	"github.com/tinsfox/gwt/pkg/gwt"
+)
+
+var compareJSON bool
+
+// compareCmd 显示两个 worktree 各自独有的提交
+var compareCmd = &cobra.Command{
+	Use:   "compare <a> [b]",
+	Short: "显示两个 worktree 各自独有的提交",
+	Long: `比较两个 worktree 的 HEAD，分别列出只在 a 中和只在 b 中的提交，
+以及两边的共同祖先。不指定 b 时与当前所在的 worktree 比较。`,
+	Example: `  # 比较 feature/login 与当前 worktree
+  gwt compare feature/login
+
+  # 比较两个 worktree
+  gwt compare main feature/login
+
+  # 以 JSON 格式输出
+  gwt compare main feature/login --json`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runCompare,
+}
+
+func init() {
+	rootCmd.AddCommand(compareCmd)
+
+	compareCmd.Flags().BoolVar(&compareJSON, "json", false, "以 JSON 格式输出")
+}
+
+// compareOutput 是 compare --json 的输出格式
+type compareOutput struct {
+	From      compareSide `json:"from"`
+	To        compareSide `json:"to"`
+	MergeBase string      `json:"merge_base"`
+}
+
+// compareSide 是 compare --json 中一侧的 worktree 及其独有的提交
+type compareSide struct {
+	Path    string         `json:"path"`
+	Branch  string         `json:"branch"`
+	Commits []commitOutput `json:"commits"`
+}
+
+// commitOutput 是 JSON 输出中的提交
+type commitOutput struct {
+	Hash    string    `json:"hash"`
+	Subject string    `json:"subject"`
+	Author  string    `json:"author"`
+	Date    time.Time `json:"date"`
+}
+
+func runCompare(cmd *cobra.Command, args []string) error {
+	from, to := args[0], ""
+	if len(args) > 1 {
+		to = args[1]
+	}
+
+	result, err := newClient(cmd).Compare(cmd.Context(), from, to)
+	if err != nil {
+		return err
+	}
+
+	if compareJSON {
+		return printJSON(compareOutput{
+			From:      newCompareSide(result.From, result.OnlyFrom),
+			To:        newCompareSide(result.To, result.OnlyTo),
+			MergeBase: result.MergeBase,
+		})
+	}
+
+	fromName := describeWorktree(result.From)
+	toName := describeWorktree(result.To)
+
+	if result.MergeBase != "" {
+		i18n.Printf("共同祖先: %s\n", color.YellowString(result.MergeBase[:7]))
+	} else {
+		i18n.Printf("共同祖先: %s\n", color.RedString(i18n.T("无")))
+	}
+
+	printUniqueCommits(fromName, "<", result.OnlyFrom)
+	printUniqueCommits(toName, ">", result.OnlyTo)
+
+	return nil
+}
+
+// printUniqueCommits 输出一侧独有的提交
+func printUniqueCommits(name, marker string, commits []gwt.Commit) {
+	fmt.Println()
+	if len(commits) == 0 {
+		i18n.Printf("%s 没有独有的提交\n", color.CyanString(name))
+		return
+	}
+
+	i18n.Printf("%s 独有 %d 个提交:\n", color.CyanString(name), len(commits))
+	for _, commit := range commits {
+		fmt.Printf("  %s %s %s %s\n", marker, color.YellowString(commit.Hash[:7]), commit.Subject,
+			color.HiBlackString("(%s, %s)", commit.Author, commit.Date.Format("2006-01-02")))
+	}
+}
+
+// newCompareSide 将一侧的比较结果转换为 JSON 输出格式
+func newCompareSide(wt gwt.Worktree, commits []gwt.Commit) compareSide {
+	side := compareSide{
+		Path:    wt.Path,
+		Branch:  wt.Branch,
+		Commits: []commitOutput{},
+	}
+	for _, commit := range commits {
+		side.Commits = append(side.Commits, commitOutput{
+			Hash:    commit.Hash,
+			Subject: commit.Subject,
+			Author:  commit.Author,
+			Date:    commit.Date,
+		})
+	}
+	return side
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/tinsfox/gwt/internal/i18n"
 )
 
 // configCmd 配置管理
@@ -56,11 +57,11 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 	if err := viper.WriteConfig(); err != nil {
 		// 如果配置文件不存在，创建新的
 		if err := viper.SafeWriteConfig(); err != nil {
-			return fmt.Errorf("保存配置失败: %w", err)
+			return i18n.Errorf("保存配置失败: %w", err)
 		}
 	}
 
-	fmt.Printf("设置 %s = %s\n", key, value)
+	i18n.Printf("设置 %s = %s\n", key, value)
 	return nil
 }
 
@@ -69,7 +70,7 @@ func runConfigGet(cmd *cobra.Command, args []string) error {
 	value := viper.Get(key)
 
 	if value == nil {
-		return fmt.Errorf("配置项不存在: %s", key)
+		return i18n.Errorf("配置项不存在: %s", key)
 	}
 
 	fmt.Printf("%s = %v\n", key, value)
@@ -80,11 +81,11 @@ func runConfigList(cmd *cobra.Command, args []string) error {
 	settings := viper.AllSettings()
 
 	if len(settings) == 0 {
-		fmt.Println("没有配置项")
+		fmt.Println(i18n.T("没有配置项"))
 		return nil
 	}
 
-	fmt.Println("当前配置:")
+	fmt.Println(i18n.T("当前配置:"))
 	for key, value := range settings {
 		fmt.Printf("  %s = %v\n", key, value)
 	}
@@ -2,29 +2,59 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/tinsfox/gwt/internal/git"
+	"github.com/tinsfox/gwt/internal/i18n"
+	"github.com/tinsfox/gwt/internal/templates"
+	"github.com/tinsfox/gwt/pkg/gwt"
 )
 
 var (
 	createBranch string
 	createPath   string
 	createForce  bool
+	createDetach bool
+	createOrphan bool
+	createSeed   string
+
+	createTemplate string
+	createNoHooks  bool
+	createNoEditor bool
+	createNoVerify bool
+	createNoShare  bool
+	createDeps     bool
+	createNoDeps   bool
+
+	createSparse         []string
+	createSparseProfiles []string
+
+	createDescription string
+	createTicket      string
+	createOwner       string
+	createTags        []string
 )
 
 // createCmd 创建新的 worktree
 var createCmd = &cobra.Command{
-	Use:     "create <branch> [path]",
+	Use:     "create <branch|name> [path]",
 	Aliases: []string{"add", "new"},
 	Short:   "创建新的 Git worktree",
 	Long: `创建一个新的 Git worktree，基于指定的分支。
 	
 如果分支不存在，会自动创建新分支。
-如果没有指定路径，会使用分支名作为目录名。`,
+如果没有指定路径，会使用分支名作为目录名。
+
+使用 --detach 时，第一个参数是要检出的标签、提交或远程分支，不创建分支，
+worktree 处于分离 HEAD 状态，适合查看发布版本或复现问题。
+
+使用 --orphan 时，在没有任何提交的新分支上创建空的 worktree，适合 gh-pages 等
+与主线无关的分支；--seed 把指定目录中的文件作为初始提交。
+
+使用 --template 时，第一个参数是传给模板的名称（如工单号），分支名、基准分支、
+路径、钩子和编辑器由模板决定，参见 gwt template --help。`,
 	Example: `  # 创建基于 main 分支的 worktree
   gwt create main
   
@@ -34,8 +64,17 @@ var createCmd = &cobra.Command{
   # 指定路径
   gwt create feature/login /tmp/login-feature
   
+  # 以分离 HEAD 检出 v1.2.0 标签
+  gwt create --detach v1.2.0
+
+  # 以 docs/site-template 中的文件创建 gh-pages 孤儿分支
+  gwt create --orphan gh-pages --seed docs/site-template
+
   # 强制创建（如果目录已存在）
-  gwt create hotfix/critical -f`,
+  gwt create hotfix/critical -f
+
+  # 使用 hotfix 模板创建
+  gwt create --template hotfix ISSUE-123`,
 	Args: cobra.RangeArgs(1, 2),
 	RunE: runCreate,
 }
@@ -46,90 +85,246 @@ func init() {
 	createCmd.Flags().StringVarP(&createBranch, "branch", "b", "", "基于的分支（默认: 当前分支）")
 	createCmd.Flags().StringVarP(&createPath, "path", "p", "", "worktree 路径（默认: 分支名）")
 	createCmd.Flags().BoolVarP(&createForce, "force", "f", false, "强制创建，即使目录已存在")
+	createCmd.Flags().BoolVarP(&createDetach, "detach", "d", false, "以分离 HEAD 检出参数指定的标签或提交，不创建分支")
+	createCmd.Flags().BoolVar(&createOrphan, "orphan", false, "在没有任何提交的新分支上创建空的 worktree")
+	createCmd.Flags().StringVar(&createSeed, "seed", "", "把该目录中的文件作为孤儿分支的初始提交（与 --orphan 一起使用）")
+	createCmd.Flags().StringVarP(&createTemplate, "template", "t", "", "使用 worktree 模板，参数作为模板中的名称")
+	createCmd.Flags().BoolVar(&createNoHooks, "no-hooks", false, "不执行模板中的钩子")
+	createCmd.Flags().BoolVar(&createNoEditor, "no-editor", false, "不使用模板中的编辑器打开")
+	createCmd.Flags().BoolVar(&createNoVerify, "no-verify", false, "跳过分支命名规则检查")
+	createCmd.Flags().StringSliceVar(&createSparse, "sparse", nil, "只检出这些目录（cone 模式稀疏检出，可重复或用逗号分隔）")
+	createCmd.Flags().StringSliceVar(&createSparseProfiles, "sparse-profile", nil, "使用 sparse_profiles 中的目录组稀疏检出")
+	createCmd.Flags().BoolVar(&createDeps, "deps", false, "从其他 worktree 复用锁文件一致的依赖目录（忽略 deps.enabled）")
+	createCmd.Flags().BoolVar(&createNoDeps, "no-deps", false, "不复用依赖目录")
+	createCmd.Flags().BoolVar(&createNoShare, "no-share", false, "不从主工作区共享 shared_files 中的文件")
+	createCmd.Flags().StringVar(&createDescription, "description", "", "记录 worktree 的用途说明")
+	createCmd.Flags().StringVar(&createTicket, "ticket", "", "记录关联的工单")
+	createCmd.Flags().StringVar(&createOwner, "owner", "", "记录负责人")
+	createCmd.Flags().StringSliceVar(&createTags, "tag", nil, "添加标签，用于 gwt list --tag 筛选（可重复或用逗号分隔）")
 }
 
 func runCreate(cmd *cobra.Command, args []string) error {
 	branch := args[0]
+	base := createBranch
 
 	// 确定路径
 	path := createPath
-	if path == "" {
-		if len(args) > 1 {
-			path = args[1]
-		} else {
-			// 使用分支名作为路径
-			path = branch
+	if path == "" && len(args) > 1 {
+		path = args[1]
+	}
+
+	if createDetach {
+		if createTemplate != "" {
+			return i18n.Errorf("--detach 不能与 --template 同时使用")
 		}
+		if createBranch != "" {
+			return i18n.Errorf("--detach 不能与 --branch 同时使用，请把要检出的提交作为参数")
+		}
+		base, branch = branch, ""
+	}
+	if createOrphan && (createDetach || createBranch != "") {
+		return i18n.Errorf("--orphan 不能与 --detach 或 --branch 同时使用")
+	}
+	if createSeed != "" && !createOrphan {
+		return i18n.Errorf("--seed 需要与 --orphan 一起使用")
 	}
 
-	// 转换为绝对路径
-	absPath, err := filepath.Abs(path)
+	repo, err := git.OpenRepository(".")
 	if err != nil {
-		return fmt.Errorf("转换路径失败: %w", err)
+		return err
 	}
 
-	// 检查是否在 git 仓库中
-	repo, err := git.OpenRepository(".")
+	var tpl *templates.Template
+	var vars templates.Vars
+	if createTemplate != "" {
+		tpl, err = findTemplate(repo, createTemplate)
+		if err != nil {
+			return err
+		}
+
+		vars = templates.NewVars(tpl.Name, args[0], repositoryName(repo))
+		if branch, err = tpl.BranchName(vars); err != nil {
+			return err
+		}
+		if path == "" {
+			if path, err = templatePath(repo, tpl, vars); err != nil {
+				return err
+			}
+		}
+		if base == "" {
+			base = tpl.Base
+		}
+
+		if tpl.Fetch {
+			remote := repo.RemoteOf(base)
+			if !quiet {
+				name := remote
+				if name == "" {
+					name = i18n.T("所有远程")
+				}
+				i18n.Printf("获取远程更新: %s\n", color.CyanString(name))
+			}
+			if err := repo.WithContext(cmd.Context()).Fetch(remote); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !createNoVerify && !createDetach {
+		if err := verifyBranchName(repo, branch); err != nil {
+			return err
+		}
+	}
+
+	sparse, err := resolveSparseDirs(repo, createSparse, createSparseProfiles)
 	if err != nil {
-		return fmt.Errorf("不是 Git 仓库: %w", err)
+		return err
 	}
 
-	// 检查分支是否存在
-	branchExists, err := repo.BranchExists(branch)
+	result, err := newClient(cmd).Create(cmd.Context(), gwt.CreateOptions{
+		Branch: branch,
+		Path:   path,
+		Base:   base,
+		Detach: createDetach,
+		Orphan: createOrphan,
+		Seed:   createSeed,
+		Force:  createForce,
+		Sparse: sparse,
+		Metadata: gwt.Metadata{
+			Description: createDescription,
+			Ticket:      createTicket,
+			Owner:       createOwner,
+			Tags:        createTags,
+		},
+	})
 	if err != nil {
-		return fmt.Errorf("检查分支失败: %w", err)
+		return err
 	}
+	worktree := result.Worktree
 
-	// 显示操作信息
+	// 显示成功信息
 	if !quiet {
-		fmt.Printf("创建 worktree:\n")
-		fmt.Printf("  分支: %s\n", color.CyanString(branch))
-		fmt.Printf("  路径: %s\n", color.YellowString(absPath))
+		i18n.Printf("创建 worktree:\n")
+		printCreatedRef(worktree)
+		i18n.Printf("  路径: %s\n", color.YellowString(worktree.Path))
+		if tpl != nil {
+			i18n.Printf("  模板: %s\n", color.CyanString(tpl.Name))
+		}
+		if meta := formatMetadata(worktree.Metadata); meta != "" {
+			i18n.Printf("  说明: %s\n", meta)
+		}
+		if worktree.Sparse {
+			i18n.Printf("  稀疏检出: %s\n", color.BlueString(formatSparseDirs(worktree.SparseDirs)))
+		}
+		switch {
+		case createOrphan && createSeed != "":
+			i18n.Printf("  操作: %s\n", color.YellowString(i18n.T("创建孤儿分支，初始提交来自 %s", createSeed)))
+		case createOrphan:
+			i18n.Printf("  操作: %s\n", color.YellowString(i18n.T("创建孤儿分支，第一次提交时创建分支")))
+		case result.NewBranch:
+			i18n.Printf("  操作: %s\n", color.YellowString(i18n.T("创建新分支")))
+		}
 
-		if !branchExists {
-			fmt.Printf("  操作: %s\n", color.YellowString("创建新分支"))
+		fmt.Println()
+		fmt.Printf("✅ %s\n", color.GreenString(i18n.T("worktree 创建成功！")))
+		i18n.Printf("   路径: %s\n", worktree.Path)
+		editTarget := worktree.Branch
+		if worktree.Branch != "" {
+			i18n.Printf("   分支: %s\n", color.CyanString(worktree.Branch))
+		} else {
+			i18n.Printf("   提交: %s\n", color.CyanString(worktree.Describe))
+			editTarget = worktree.Path
 		}
+		fmt.Println()
+		fmt.Printf("💡 %s\n", color.BlueString(i18n.T("提示:")))
+		i18n.Printf("   cd %s    # 进入 worktree 目录\n", worktree.Path)
+		i18n.Printf("   gwt edit %s  # 用编辑器打开\n", editTarget)
 	}
 
-	// 检查目标目录
-	if _, err := os.Stat(absPath); err == nil {
-		if !createForce {
-			return fmt.Errorf("目录已存在: %s，使用 -f 强制创建", absPath)
-		}
+	// 孤儿分支与其他 worktree 没有共同的文件和 .gitignore，不共享文件也不复用依赖
+	if !createNoShare && !createOrphan {
+		shareCreatedWorktree(repo, worktree.Path)
+	}
+	if !createNoDeps && !createOrphan {
+		reuseDependencies(repo, worktree.Path, createDeps)
+	}
 
-		if !quiet {
-			fmt.Printf("  警告: %s\n", color.YellowString("目录已存在，强制创建"))
+	if tpl == nil {
+		return nil
+	}
+
+	if !createNoHooks {
+		if err := runTemplateHooks(cmd, tpl, vars, worktree); err != nil {
+			return err
 		}
 	}
 
-	// 创建 worktree
-	options := git.CreateWorktreeOptions{
-		Branch:       branch,
-		Path:         absPath,
-		CreateBranch: !branchExists,
-		Force:        createForce,
+	if tpl.Editor != "" && !createNoEditor {
+		return openInEditor(worktree.Path, tpl.Editor)
 	}
 
-	if createBranch != "" {
-		options.BaseBranch = createBranch
+	return nil
+}
+
+// printCreatedRef 显示新建 worktree 检出的分支，分离 HEAD 时显示提交的描述
+func printCreatedRef(worktree gwt.Worktree) {
+	if worktree.Branch != "" {
+		i18n.Printf("  分支: %s\n", color.CyanString(worktree.Branch))
+		return
 	}
+	i18n.Printf("  提交: %s\n", color.CyanString(i18n.T("%s（分离 HEAD）", worktree.Describe)))
+}
 
-	worktree, err := repo.CreateWorktree(options)
+// shareCreatedWorktree 把 shared_files 中的文件共享到新建的 worktree
+//
+// worktree 已经创建成功，共享失败时只给出警告。
+func shareCreatedWorktree(repo *git.Repository, path string) {
+	shared, err := loadSharedFiles(repo)
+	if err == nil && shared != nil {
+		var applied int
+		applied, _, err = shared.apply(path, false)
+		if !quiet && applied > 0 {
+			i18n.Printf("🔗 从主工作区共享了 %d 个文件\n", applied)
+		}
+	}
 	if err != nil {
-		return fmt.Errorf("创建 worktree 失败: %w", err)
+		fmt.Printf("⚠️  %s\n", color.YellowString(i18n.T("共享文件失败: %v", err)))
 	}
+}
 
-	// 显示成功信息
-	if !quiet {
-		fmt.Println()
-		fmt.Printf("✅ %s\n", color.GreenString("worktree 创建成功！"))
-		fmt.Printf("   路径: %s\n", worktree.Path)
-		fmt.Printf("   分支: %s\n", color.CyanString(worktree.Branch))
-		fmt.Println()
-		fmt.Printf("💡 %s\n", color.BlueString("提示:"))
-		fmt.Printf("   cd %s    # 进入 worktree 目录\n", worktree.Path)
-		fmt.Printf("   gwt edit %s  # 用编辑器打开\n", worktree.Branch)
+// templatePath 展开模板中的路径，相对路径基于主工作区（裸仓库布局中为仓库目录）
+func templatePath(repo *git.Repository, tpl *templates.Template, vars templates.Vars) (string, error) {
+	path, err := tpl.WorktreePath(vars)
+	if err != nil || path == "" || filepath.IsAbs(path) {
+		return path, err
+	}
+
+	root := repo.LayoutRoot()
+	if root == "" {
+		root = repo.MainWorktree
+	}
+	if root == "" {
+		return path, nil
+	}
+	return filepath.Join(root, path), nil
+}
+
+// runTemplateHooks 在新建的 worktree 中依次执行模板的钩子，遇到失败时停止
+func runTemplateHooks(cmd *cobra.Command, tpl *templates.Template, vars templates.Vars, worktree gwt.Worktree) error {
+	env := []string{
+		"GWT_WORKTREE_PATH=" + worktree.Path,
+		"GWT_BRANCH=" + worktree.Branch,
+		"GWT_TEMPLATE=" + tpl.Name,
+		"GWT_NAME=" + vars.Name,
 	}
 
+	for _, hook := range tpl.Hooks {
+		if !quiet {
+			i18n.Printf("🔧 执行钩子: %s\n", color.CyanString(hook))
+		}
+		if err := templates.RunHook(cmd.Context(), hook, worktree.Path, env, cmd.OutOrStdout(), cmd.ErrOrStderr()); err != nil {
+			return i18n.Errorf("%w（worktree 已创建: %s）", err, worktree.Path)
+		}
+	}
 	return nil
 }
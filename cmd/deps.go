@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/tinsfox/gwt/internal/deps"
+	"github.com/tinsfox/gwt/internal/git"
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+// dependencySource 是可以提供依赖目录的 worktree 及其与新 worktree 的提交距离
+type dependencySource struct {
+	wt       git.WorktreeInfo
+	distance int
+}
+
+// reuseDependencies 从最接近的 worktree 复制锁文件一致的依赖目录到 target
+//
+// force 为 true 时忽略 deps.enabled。worktree 已经创建成功，失败时只给出警告。
+func reuseDependencies(repo *git.Repository, target string, force bool) {
+	var config deps.Config
+	if err := unmarshalConfig(repo, "deps", &config); err != nil {
+		fmt.Printf("⚠️  %s\n", color.YellowString(err.Error()))
+		return
+	}
+	if !config.Enabled && !force {
+		return
+	}
+
+	sources, err := dependencySources(repo, target)
+	if err != nil {
+		fmt.Printf("⚠️  %s\n", color.YellowString(i18n.T("复用依赖目录失败: %v", err)))
+		return
+	}
+
+	for _, dir := range config.Merge() {
+		if err := reuseDependencyDir(dir, config.Method, sources, target); err != nil {
+			fmt.Printf("⚠️  %s\n", color.YellowString(i18n.T("复用 %s 失败: %v", dir.Path, err)))
+		}
+	}
+}
+
+// reuseDependencyDir 复用一个依赖目录，sources 按与 target 的接近程度排序
+func reuseDependencyDir(dir deps.Dir, defaultMethod string, sources []dependencySource, target string) error {
+	if dir.Exists(target) {
+		return nil
+	}
+
+	hash, err := dir.LockHash(target)
+	if err != nil {
+		return err
+	}
+	if hash == "" {
+		return nil
+	}
+
+	method := dir.Method
+	if method == "" {
+		method = defaultMethod
+	}
+	cloneMethod, err := deps.ParseMethod(method)
+	if err != nil {
+		return err
+	}
+
+	for _, source := range sources {
+		if !dir.Exists(source.wt.Path) {
+			continue
+		}
+		if sourceHash, err := dir.LockHash(source.wt.Path); err != nil || sourceHash != hash {
+			if verbose {
+				i18n.Printf("跳过 %s 中的 %s: 锁文件不一致\n", source.wt.Path, dir.Path)
+			}
+			continue
+		}
+
+		used, err := deps.Clone(filepath.Join(source.wt.Path, dir.Path), filepath.Join(target, dir.Path), cloneMethod)
+		if err != nil {
+			return err
+		}
+		if !quiet {
+			i18n.Printf("📦 从 %s 复用了 %s（%s）\n", color.CyanString(describeWorktree(source.wt)), dir.Path, used)
+		}
+		return nil
+	}
+
+	return nil
+}
+
+// dependencySources 返回除 target 外的 worktree，按与 target 的提交距离从近到远排序
+func dependencySources(repo *git.Repository, target string) ([]dependencySource, error) {
+	worktrees, err := repo.ListWorktrees()
+	if err != nil {
+		return nil, i18n.Errorf("获取 worktree 列表失败: %w", err)
+	}
+
+	head, err := repo.RevParse(target, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	var sources []dependencySource
+	for _, wt := range worktrees {
+		if wt.Path == target || wt.Prunable != "" || !pathExists(wt.Path) {
+			continue
+		}
+
+		// 无法比较提交（如没有提交的分支）时排在最后
+		distance := int(^uint(0) >> 1)
+		if other, err := repo.RevParse(wt.Path, "HEAD"); err == nil {
+			if d, err := repo.CommitDistance(head, other); err == nil {
+				distance = d
+			}
+		}
+		sources = append(sources, dependencySource{wt: wt, distance: distance})
+	}
+
+	sort.SliceStable(sources, func(i, j int) bool {
+		return sources[i].distance < sources[j].distance
+	})
+	return sources, nil
+}
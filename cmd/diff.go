@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tinsfox/gwt/internal/i18n"
+	"github.com/tinsfox/gwt/pkg/gwt"
+)
+
+var (
+	diffWorkingTree bool
+	diffStat        bool
+	diffNameOnly    bool
+	diffTool        bool
+)
+
+// diffCmd 比较两个 worktree
+var diffCmd = &cobra.Command{
+	Use:   "diff <a> [b] [-- <path>...]",
+	Short: "比较两个 worktree 的差异",
+	Long: `比较两个 worktree，显示从 a 到 b 的变更。不指定 b 时与当前所在的 worktree 比较。
+
+默认比较两个 worktree 的 HEAD 提交；使用 -w 时比较两边当前的文件，
+包括未提交的修改和未跟踪文件（不会改动任何一边的暂存区）。
+-- 之后的参数用于限制比较的路径。使用 --tool 时打开 git 配置的 difftool（diff.tool）。`,
+	Example: `  # 比较 feature/login 与当前 worktree 的提交
+  gwt diff feature/login
+
+  # 比较两个 worktree 当前的文件，只看统计
+  gwt diff main feature/login -w --stat
+
+  # 只比较 src 目录，列出变更的文件
+  gwt diff main feature/login --name-only -- src/
+
+  # 使用 difftool 比较
+  gwt diff main feature/login --tool`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+			args = args[:dash]
+		}
+		return cobra.RangeArgs(1, 2)(cmd, args)
+	},
+	RunE: runDiff,
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().BoolVarP(&diffWorkingTree, "worktree", "w", false, "比较当前的文件，包括未提交的修改和未跟踪文件")
+	diffCmd.Flags().BoolVar(&diffStat, "stat", false, "只显示变更统计")
+	diffCmd.Flags().BoolVar(&diffNameOnly, "name-only", false, "只显示变更的文件名")
+	diffCmd.Flags().BoolVarP(&diffTool, "tool", "t", false, "使用 git 配置的 difftool 比较")
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	targets, paths := splitAtDash(cmd, args)
+
+	from, to := targets[0], ""
+	if len(targets) > 1 {
+		to = targets[1]
+	}
+
+	return newClient(cmd).Diff(cmd.Context(), from, to, gwt.DiffOptions{
+		WorkingTree: diffWorkingTree,
+		Stat:        diffStat,
+		NameOnly:    diffNameOnly,
+		Paths:       paths,
+		Color:       !color.NoColor,
+		Tool:        diffTool,
+	})
+}
+
+// splitAtDash 把参数分成 -- 之前和之后两部分
+func splitAtDash(cmd *cobra.Command, args []string) ([]string, []string) {
+	dash := cmd.ArgsLenAtDash()
+	if dash < 0 {
+		return args, nil
+	}
+	return args[:dash], args[dash:]
+}
+
+// describeWorktree 返回用于显示的 worktree 名称
+func describeWorktree(wt gwt.Worktree) string {
+	if wt.Branch != "" {
+		return wt.Branch
+	}
+	return i18n.T("%s（分离 HEAD）", wt.Path)
+}
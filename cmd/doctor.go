@@ -0,0 +1,560 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	editorpkg "github.com/tinsfox/gwt/internal/editor"
+	"github.com/tinsfox/gwt/internal/git"
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+var doctorFix bool
+
+// staleLockAge 超过这个时间的 .lock 文件视为 git 异常退出后遗留的
+const staleLockAge = 10 * time.Minute
+
+// doctorCmd 检查 worktree 环境
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "检查仓库的 worktree 环境是否健康",
+	Long: `依次检查 git 版本、worktree 链接、丢失的 worktree 目录、重复检出的分支、
+遗留的锁文件、配置文件、编辑器、shell 补全以及分支命名规则。
+
+每项检查的结果为通过、警告或失败，并给出修复建议。使用 --fix 时会自动执行
+安全的修复：修复 worktree 链接、清理目录已删除（上级目录仍存在）的 worktree、
+删除遗留的锁文件。有检查失败时以非零状态退出。`,
+	Example: `  # 检查当前仓库
+  gwt doctor
+
+  # 检查并自动修复
+  gwt doctor --fix`,
+	Args: cobra.NoArgs,
+	RunE: runDoctor,
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+
+	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "自动执行安全的修复")
+}
+
+// checkStatus 检查结果的状态
+type checkStatus int
+
+const (
+	checkPass checkStatus = iota
+	checkWarn
+	checkFail
+	checkSkip
+)
+
+// checkResult 单项检查的结果
+type checkResult struct {
+	status  checkStatus
+	message string
+	details []string
+	hint    string
+	// repair 是 --fix 时执行的安全修复，没有可以自动修复的问题时为 nil
+	repair func() error
+}
+
+// doctorCheck 一项检查，needsRepo 为 true 的检查在仓库外跳过
+type doctorCheck struct {
+	name      string
+	needsRepo bool
+	run       func(ctx context.Context, repo *git.Repository) checkResult
+}
+
+// doctorChecks 返回按顺序执行的检查，名称在设置界面语言之后翻译
+func doctorChecks() []doctorCheck {
+	return []doctorCheck{
+		{i18n.T("Git 版本"), false, checkGitVersion},
+		{i18n.T("worktree 链接"), true, checkWorktreeLinks},
+		{i18n.T("worktree 目录"), true, checkWorktreePaths},
+		{i18n.T("重复检出"), true, checkDuplicateBranches},
+		{i18n.T("锁文件"), true, checkLockFiles},
+		{i18n.T("配置文件"), false, checkConfigFiles},
+		{i18n.T("编辑器"), false, checkEditor},
+		{i18n.T("Shell 补全"), false, checkShellCompletion},
+		{i18n.T("分支命名"), true, checkBranchNames},
+	}
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	repo, err := git.Open(ctx, ".", nil)
+	if err != nil && !errors.Is(err, git.ErrNotRepository) {
+		return err
+	}
+
+	counts := make(map[checkStatus]int)
+	for _, check := range doctorChecks() {
+		var result checkResult
+		if check.needsRepo && repo == nil {
+			result = checkResult{status: checkSkip, message: i18n.T("不在 Git 仓库中")}
+		} else {
+			result = check.run(ctx, repo)
+		}
+
+		// 修复后重新检查，只能修复部分问题时如实报告剩下的问题
+		fixed := false
+		var fixErr error
+		if doctorFix && result.repair != nil && result.status != checkPass {
+			if fixErr = result.repair(); fixErr == nil {
+				fixed = true
+				result = check.run(ctx, repo)
+			}
+		}
+
+		counts[result.status]++
+		printCheckResult(check.name, result, fixed, fixErr)
+	}
+
+	if !quiet {
+		fmt.Println()
+		i18n.Printf("%d 项通过，%d 项警告，%d 项失败\n", counts[checkPass], counts[checkWarn], counts[checkFail])
+	}
+
+	if counts[checkFail] > 0 {
+		return i18n.Errorf("%d 项检查失败", counts[checkFail])
+	}
+	return nil
+}
+
+// printCheckResult 输出单项检查的结果和修复建议
+func printCheckResult(name string, result checkResult, fixed bool, fixErr error) {
+	if quiet && result.status != checkFail {
+		return
+	}
+
+	var icon string
+	switch result.status {
+	case checkPass:
+		icon = "✅"
+	case checkWarn:
+		icon = "⚠️ "
+	case checkFail:
+		icon = "❌"
+	default:
+		icon = "⏭️ "
+	}
+
+	fmt.Printf("%s %s: %s\n", icon, color.CyanString(name), result.message)
+	for _, detail := range result.details {
+		fmt.Printf("   - %s\n", detail)
+	}
+
+	switch {
+	case fixed && result.status == checkPass:
+		fmt.Printf("   🔧 %s\n", color.GreenString(i18n.T("已修复")))
+		return
+	case fixed:
+		fmt.Printf("   🔧 %s\n", color.GreenString(i18n.T("已修复可以自动修复的问题")))
+	case fixErr != nil:
+		fmt.Printf("   🔧 %s\n", color.RedString(i18n.T("修复失败: %v", fixErr)))
+	}
+
+	if result.status == checkPass || result.status == checkSkip {
+		return
+	}
+	hint := result.hint
+	if result.repair != nil && !fixed && fixErr == nil {
+		hint += i18n.T("（运行 gwt doctor --fix 自动修复）")
+	}
+	if hint != "" {
+		fmt.Printf("   💡 %s\n", color.BlueString(hint))
+	}
+}
+
+// checkGitVersion 检查 git 版本是否支持 gwt 使用的 worktree 功能
+func checkGitVersion(ctx context.Context, repo *git.Repository) checkResult {
+	version, err := git.GetVersion(ctx, nil)
+	if err != nil {
+		return checkResult{
+			status:  checkFail,
+			message: err.Error(),
+			hint:    i18n.T("安装 git，或用 git.path 配置（GWT_GIT_PATH）指定 git 的路径"),
+		}
+	}
+
+	switch {
+	case !version.AtLeast(git.MinVersion):
+		return checkResult{
+			status:  checkFail,
+			message: i18n.T("git %s 低于要求的最低版本 %s", version, git.MinVersion),
+			hint:    i18n.T("升级 git"),
+		}
+	case !version.AtLeast(git.RecommendedVersion):
+		return checkResult{
+			status:  checkWarn,
+			message: i18n.T("git %s 低于推荐版本 %s，部分功能（如标记可清理的 worktree）不可用", version, git.RecommendedVersion),
+			hint:    i18n.T("升级 git"),
+		}
+	}
+	return checkResult{status: checkPass, message: "git " + version.String()}
+}
+
+// checkWorktreeLinks 检查 worktree 的 .git 文件是否指回对应的管理目录
+func checkWorktreeLinks(ctx context.Context, repo *git.Repository) checkResult {
+	admins, err := repo.WorktreeAdmins()
+	if err != nil {
+		return checkResult{status: checkFail, message: err.Error()}
+	}
+
+	var details []string
+	repairable := false
+	for _, admin := range admins {
+		if err := admin.CheckLink(); err != nil {
+			details = append(details, err.Error())
+			repairable = repairable || admin.Path != ""
+		}
+	}
+
+	if len(details) == 0 {
+		return checkResult{status: checkPass, message: i18n.T("%d 个链接 worktree 正常", len(admins))}
+	}
+
+	result := checkResult{
+		status:  checkFail,
+		message: i18n.T("%d 个 worktree 的链接已损坏", len(details)),
+		details: details,
+		hint:    i18n.T("在主工作区运行 git worktree repair 修复"),
+	}
+	if repairable {
+		// 不带参数时 git 按管理目录中记录的路径重写各 worktree 的 .git 文件
+		result.repair = func() error {
+			return repo.RepairWorktrees()
+		}
+	}
+	return result
+}
+
+// checkWorktreePaths 检查 worktree 目录是否存在，区分已删除和可能未挂载的目录
+func checkWorktreePaths(ctx context.Context, repo *git.Repository) checkResult {
+	admins, err := repo.WorktreeAdmins()
+	if err != nil {
+		return checkResult{status: checkFail, message: err.Error()}
+	}
+
+	var removable []git.WorktreeAdmin
+	var details []string
+	for _, admin := range admins {
+		if admin.Path == "" {
+			continue
+		}
+		if _, err := os.Stat(admin.Path); !os.IsNotExist(err) {
+			continue
+		}
+
+		switch {
+		case admin.Locked:
+			details = append(details, i18n.T("%s: 目录不存在（已锁定，不会清理）", admin.Path))
+		case !pathExists(filepath.Dir(admin.Path)):
+			details = append(details, i18n.T("%s: 上级目录也不存在，可能位于未挂载的磁盘上", admin.Path))
+		default:
+			details = append(details, i18n.T("%s: 目录已删除", admin.Path))
+			removable = append(removable, admin)
+		}
+	}
+
+	if len(details) == 0 {
+		return checkResult{status: checkPass, message: i18n.T("所有 worktree 目录都存在")}
+	}
+
+	result := checkResult{
+		status:  checkWarn,
+		message: i18n.T("%d 个 worktree 的目录不存在", len(details)),
+		details: details,
+		hint:    i18n.T("挂载对应的磁盘；目录被移动过时在新位置运行 git worktree repair；确认目录不会恢复后运行 gwt prune"),
+	}
+	if len(removable) > 0 {
+		result.repair = func() error {
+			for _, admin := range removable {
+				if err := repo.RemoveWorktreeAdmin(admin); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	return result
+}
+
+// checkDuplicateBranches 检查是否有分支同时在多个 worktree 中检出
+func checkDuplicateBranches(ctx context.Context, repo *git.Repository) checkResult {
+	worktrees, err := repo.ListWorktrees()
+	if err != nil {
+		return checkResult{status: checkFail, message: err.Error()}
+	}
+
+	paths := make(map[string][]string)
+	for _, wt := range worktrees {
+		if wt.Branch != "" {
+			paths[wt.Branch] = append(paths[wt.Branch], wt.Path)
+		}
+	}
+
+	var details []string
+	for branch, list := range paths {
+		if len(list) > 1 {
+			details = append(details, fmt.Sprintf("%s: %s", branch, strings.Join(list, ", ")))
+		}
+	}
+	sort.Strings(details)
+
+	if len(details) == 0 {
+		return checkResult{status: checkPass, message: i18n.T("没有分支在多个 worktree 中检出")}
+	}
+	return checkResult{
+		status:  checkFail,
+		message: i18n.T("%d 个分支在多个 worktree 中检出", len(details)),
+		details: details,
+		hint:    i18n.T("在多余的 worktree 中切换到其他分支，或用 gwt remove 删除"),
+	}
+}
+
+// checkLockFiles 检查 git 异常退出后遗留的 .lock 文件
+func checkLockFiles(ctx context.Context, repo *git.Repository) checkResult {
+	locks, err := repo.LockFiles()
+	if err != nil {
+		return checkResult{status: checkFail, message: err.Error()}
+	}
+	if len(locks) == 0 {
+		return checkResult{status: checkPass, message: i18n.T("没有遗留的锁文件")}
+	}
+
+	var stale []string
+	var details []string
+	for _, lock := range locks {
+		if time.Since(lock.ModTime) >= staleLockAge {
+			stale = append(stale, lock.Path)
+			details = append(details, i18n.T("%s（修改于 %s）", lock.Path, lock.ModTime.Format("2006-01-02 15:04")))
+		} else {
+			details = append(details, i18n.T("%s（刚刚创建，可能有 git 命令正在运行）", lock.Path))
+		}
+	}
+
+	result := checkResult{
+		status:  checkWarn,
+		message: i18n.T("发现 %d 个锁文件", len(locks)),
+		details: details,
+		hint:    i18n.T("确认没有 git 命令正在运行后删除这些文件"),
+	}
+	if len(stale) > 0 {
+		result.repair = func() error {
+			for _, path := range stale {
+				if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	return result
+}
+
+// checkConfigFiles 检查用户配置和项目配置能否解析，以及是否有未知的配置项
+func checkConfigFiles(ctx context.Context, repo *git.Repository) checkResult {
+	var errs, unknown []string
+
+	checkKeys := func(config *viper.Viper) {
+		for _, key := range config.AllKeys() {
+			if !knownConfigKey(key) {
+				unknown = append(unknown, i18n.T("%s: 未知配置项 %s", config.ConfigFileUsed(), key))
+			}
+		}
+		for _, key := range unknownListFields(config) {
+			unknown = append(unknown, i18n.T("%s: 未知配置项 %s", config.ConfigFileUsed(), key))
+		}
+	}
+
+	files := 0
+	if file := viper.ConfigFileUsed(); file != "" {
+		files++
+		config := viper.New()
+		config.SetConfigFile(file)
+		if err := config.ReadInConfig(); err != nil {
+			errs = append(errs, i18n.T("%s: %v", file, err))
+		} else {
+			checkKeys(config)
+		}
+	}
+
+	if repo != nil {
+		project, err := loadProjectConfig(repo)
+		switch {
+		case err != nil:
+			files++
+			errs = append(errs, err.Error())
+		case project != nil && project != viper.GetViper():
+			files++
+			checkKeys(project)
+		}
+
+		if _, err := loadTemplates(repo); err != nil {
+			errs = append(errs, err.Error())
+		}
+		if _, err := loadBranchPolicy(repo); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	switch {
+	case len(errs) > 0:
+		return checkResult{
+			status:  checkFail,
+			message: i18n.T("配置文件有错误"),
+			details: append(errs, unknown...),
+			hint:    i18n.T("检查配置文件的 YAML 语法和配置项的值"),
+		}
+	case len(unknown) > 0:
+		return checkResult{
+			status:  checkWarn,
+			message: i18n.T("发现 %d 个未知配置项", len(unknown)),
+			details: unknown,
+			hint:    i18n.T("检查配置项是否拼写错误，不再使用的配置项可以删除"),
+		}
+	case files == 0:
+		return checkResult{status: checkPass, message: i18n.T("没有配置文件，使用默认配置")}
+	}
+	return checkResult{status: checkPass, message: i18n.T("%d 个配置文件正常", files)}
+}
+
+// checkEditor 检查默认编辑器是否已安装
+func checkEditor(ctx context.Context, repo *git.Repository) checkResult {
+	name := viper.GetString("editor.default")
+	hint := i18n.T("运行 gwt config set editor.default <编辑器> 选择已安装的编辑器")
+	if available := editorpkg.GetAvailableEditors(); len(available) > 0 {
+		names := make([]string, 0, len(available))
+		for _, e := range available {
+			names = append(names, e.Command)
+		}
+		sort.Strings(names)
+		hint += i18n.T("，已安装: %s", strings.Join(names, ", "))
+	}
+
+	ok, err := editorpkg.IsAvailable(name)
+	switch {
+	case err != nil:
+		return checkResult{status: checkFail, message: err.Error(), hint: hint}
+	case !ok:
+		return checkResult{
+			status:  checkWarn,
+			message: i18n.T("默认编辑器 %s 未安装或不在 PATH 中，将回退到其他编辑器", name),
+			hint:    hint,
+		}
+	}
+	return checkResult{status: checkPass, message: name}
+}
+
+// checkShellCompletion 检查当前 shell 是否安装了 gwt 的自动补全
+func checkShellCompletion(ctx context.Context, repo *git.Repository) checkResult {
+	shell := filepath.Base(os.Getenv("SHELL"))
+	home, _ := os.UserHomeDir()
+
+	var files, rcFiles []string
+	switch shell {
+	case "bash":
+		files = []string{
+			"/etc/bash_completion.d/gwt",
+			"/usr/local/etc/bash_completion.d/gwt",
+			"/opt/homebrew/etc/bash_completion.d/gwt",
+			"/usr/share/bash-completion/completions/gwt",
+			filepath.Join(home, ".local/share/bash-completion/completions/gwt"),
+		}
+		rcFiles = []string{filepath.Join(home, ".bashrc"), filepath.Join(home, ".bash_profile")}
+	case "zsh":
+		files = []string{
+			"/usr/local/share/zsh/site-functions/_gwt",
+			"/opt/homebrew/share/zsh/site-functions/_gwt",
+			"/usr/share/zsh/site-functions/_gwt",
+			filepath.Join(home, ".zsh/completions/_gwt"),
+			filepath.Join(home, ".oh-my-zsh/completions/_gwt"),
+		}
+		rcFiles = []string{filepath.Join(home, ".zshrc")}
+	case "fish":
+		files = []string{filepath.Join(home, ".config/fish/completions/gwt.fish")}
+		rcFiles = []string{filepath.Join(home, ".config/fish/config.fish")}
+	default:
+		return checkResult{status: checkSkip, message: i18n.T("无法识别当前 shell: %s", os.Getenv("SHELL"))}
+	}
+
+	for _, file := range files {
+		if pathExists(file) {
+			return checkResult{status: checkPass, message: file}
+		}
+	}
+	for _, rc := range rcFiles {
+		if data, err := os.ReadFile(rc); err == nil && strings.Contains(string(data), "gwt completion") {
+			return checkResult{status: checkPass, message: rc}
+		}
+	}
+
+	return checkResult{
+		status:  checkWarn,
+		message: i18n.T("没有找到 %s 的 gwt 自动补全", shell),
+		hint:    i18n.T("运行 gwt completion --help 查看安装方法"),
+	}
+}
+
+// checkBranchNames 用 branch_policy 检查现有 worktree 的分支名
+func checkBranchNames(ctx context.Context, repo *git.Repository) checkResult {
+	policy, err := loadBranchPolicy(repo)
+	if err != nil {
+		return checkResult{status: checkFail, message: err.Error()}
+	}
+	if policy.IsZero() {
+		return checkResult{status: checkPass, message: i18n.T("没有配置 branch_policy")}
+	}
+
+	worktrees, err := repo.ListWorktrees()
+	if err != nil {
+		return checkResult{status: checkFail, message: err.Error()}
+	}
+	defaultBranch, _ := repo.DefaultBranch()
+
+	var details []string
+	checked := make(map[string]bool)
+	for _, wt := range worktrees {
+		// 主工作区和默认分支通常不遵循任务分支的命名规则
+		if wt.Branch == "" || wt.IsMain || wt.Branch == defaultBranch || checked[wt.Branch] {
+			continue
+		}
+		checked[wt.Branch] = true
+
+		if violations := policy.Validate(wt.Branch); len(violations) > 0 {
+			detail := fmt.Sprintf("%s: %s", wt.Branch, strings.Join(violations, "; "))
+			if suggestion := policy.Suggest(wt.Branch); suggestion != "" {
+				detail += i18n.T("（建议: %s）", suggestion)
+			}
+			details = append(details, detail)
+		}
+	}
+
+	if len(details) == 0 {
+		return checkResult{status: checkPass, message: i18n.T("%d 个分支符合命名规则", len(checked))}
+	}
+	return checkResult{
+		status:  checkWarn,
+		message: i18n.T("%d 个分支不符合命名规则", len(details)),
+		details: details,
+		hint:    i18n.T("使用 git branch -m <旧名称> <新名称> 重命名分支"),
+	}
+}
+
+// pathExists 检查路径是否存在
+func pathExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -12,6 +11,7 @@ import (
 	"github.com/spf13/viper"
 	editorpkg "github.com/tinsfox/gwt/internal/editor"
 	"github.com/tinsfox/gwt/internal/git"
+	"github.com/tinsfox/gwt/internal/i18n"
 )
 
 var (
@@ -94,7 +94,7 @@ func runEdit(cmd *cobra.Command, args []string) error {
 	// 检查是否在 git 仓库中
 	repo, err := git.OpenRepository(".")
 	if err != nil {
-		return fmt.Errorf("不是 Git 仓库: %w", err)
+		return err
 	}
 
 	// 确定要打开的目录
@@ -132,42 +132,50 @@ func runEdit(cmd *cobra.Command, args []string) error {
 		// 检查是否是分支名
 		branchExists, err := repo.BranchExists(target)
 		if err != nil {
-			return fmt.Errorf("检查分支失败: %w", err)
+			return i18n.Errorf("检查分支失败: %w", err)
 		}
 
 		if branchExists {
 			// 询问是否创建 worktree
-			fmt.Printf("分支 '%s' 存在但没有对应的 worktree。\n", color.CyanString(target))
-			fmt.Print("是否创建 worktree? [y/N]: ")
+			i18n.Printf("分支 '%s' 存在但没有对应的 worktree。\n", color.CyanString(target))
+			create, err := prompter.Confirm(i18n.T("是否创建 worktree?"), false)
+			if err != nil {
+				return err
+			}
 
-			var response string
-			fmt.Scanln(&response)
+			if create {
+				path, err := defaultWorktreePath(repo, target)
+				if err != nil {
+					return i18n.Errorf("转换路径失败: %w", err)
+				}
 
-			if strings.ToLower(response) == "y" || strings.ToLower(response) == "yes" {
 				// 创建 worktree
 				worktree, err := repo.CreateWorktree(git.CreateWorktreeOptions{
 					Branch: target,
-					Path:   target,
+					Path:   path,
 				})
 				if err != nil {
-					return fmt.Errorf("创建 worktree 失败: %w", err)
+					return err
 				}
 				targetPath = worktree.Path
 			} else {
-				return fmt.Errorf("取消操作")
+				return i18n.Errorf("取消操作")
 			}
 		} else {
-			return fmt.Errorf("找不到分支或路径: %s", target)
+			return i18n.Errorf("找不到分支或路径: %s", target)
 		}
 	}
 
 	// 验证目录存在
 	if _, err := os.Stat(targetPath); os.IsNotExist(err) {
-		return fmt.Errorf("目录不存在: %s", targetPath)
+		return i18n.Errorf("目录不存在: %s", targetPath)
 	}
 
-	// 确定编辑器
-	editor := editEditor
+	return openInEditor(targetPath, editEditor)
+}
+
+// openInEditor 使用编辑器打开目录，editor 为空时使用 editor.default 配置
+func openInEditor(targetPath, editor string) error {
 	if editor == "" {
 		editor = viper.GetString("editor.default")
 	}
@@ -175,20 +183,20 @@ func runEdit(cmd *cobra.Command, args []string) error {
 	// 检测编辑器
 	editorInfo, err := editorpkg.DetectEditor(editor)
 	if err != nil {
-		return fmt.Errorf("检测编辑器失败: %w", err)
+		return i18n.Errorf("检测编辑器失败: %w", err)
 	}
 
 	if !quiet {
-		fmt.Printf("使用编辑器打开:\n")
-		fmt.Printf("  目录: %s\n", color.YellowString(targetPath))
-		fmt.Printf("  编辑器: %s\n", color.CyanString(editorInfo.Name))
+		i18n.Printf("使用编辑器打开:\n")
+		i18n.Printf("  目录: %s\n", color.YellowString(targetPath))
+		i18n.Printf("  编辑器: %s\n", color.CyanString(editorInfo.Name))
 		if editorInfo.Command != "" {
-			fmt.Printf("  命令: %s\n", editorInfo.Command)
+			i18n.Printf("  命令: %s\n", editorInfo.Command)
 		}
 	}
 
 	// 构建命令参数
-	args = []string{targetPath}
+	args := []string{targetPath}
 
 	// 添加编辑器特定的参数
 	if editNewWindow && editorInfo.SupportsNewWindow {
@@ -206,7 +214,7 @@ func runEdit(cmd *cobra.Command, args []string) error {
 	cmdExec.Stdin = os.Stdin
 
 	if err := cmdExec.Run(); err != nil {
-		return fmt.Errorf("启动编辑器失败: %w", err)
+		return i18n.Errorf("启动编辑器失败: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/fatih/color"
+	"github.com/tinsfox/gwt/internal/git"
+	"github.com/tinsfox/gwt/internal/i18n"
+	"github.com/tinsfox/gwt/internal/naming"
+	"github.com/tinsfox/gwt/internal/ui"
+	"github.com/tinsfox/gwt/pkg/gwt"
+)
+
+// 进程退出码，脚本可以据此区分失败原因
+const (
+	exitError            = 1
+	exitNotRepository    = 3
+	exitNotFound         = 4
+	exitAmbiguousTarget  = 5
+	exitBranchCheckedOut = 6
+	exitBranchExists     = 7
+	exitInvalidReference = 8
+	exitPathExists       = 9
+	exitWorktreeDirty    = 10
+	exitLocked           = 11
+	exitMainWorktree     = 12
+	exitInputRequired    = 13
+	exitBranchPolicy     = 14
+	exitApplyConflict    = 15
+	exitInterrupted      = 130
+)
+
+// exitCodes 按顺序匹配错误与退出码，更具体的错误在前
+var exitCodes = []struct {
+	err  error
+	code int
+}{
+	{git.ErrNotRepository, exitNotRepository},
+	{git.ErrAmbiguousTarget, exitAmbiguousTarget},
+	{git.ErrNotFound, exitNotFound},
+	{git.ErrBranchCheckedOut, exitBranchCheckedOut},
+	{git.ErrBranchExists, exitBranchExists},
+	{git.ErrInvalidReference, exitInvalidReference},
+	{git.ErrPathExists, exitPathExists},
+	{git.ErrWorktreeDirty, exitWorktreeDirty},
+	{git.ErrLocked, exitLocked},
+	{git.ErrMainWorktree, exitMainWorktree},
+	{ui.ErrInputRequired, exitInputRequired},
+	{naming.ErrPolicyViolation, exitBranchPolicy},
+	{git.ErrApplyConflict, exitApplyConflict},
+	{context.Canceled, exitInterrupted},
+}
+
+// ExitCode 返回错误对应的进程退出码
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	for _, e := range exitCodes {
+		if errors.Is(err, e.err) {
+			return e.code
+		}
+	}
+	return exitError
+}
+
+// errorHint 返回帮助用户解决错误的提示，没有提示时返回空字符串
+func errorHint(err error) string {
+	var checkedOut *git.BranchCheckedOutError
+	if errors.As(err, &checkedOut) {
+		return i18n.T("分支 %s 已在 %s 检出；运行 `gwt switch %s` 切换过去", checkedOut.Branch, checkedOut.Path, checkedOut.Branch)
+	}
+
+	var locked *git.LockedError
+	if errors.As(err, &locked) {
+		return i18n.T("运行 `gwt unlock %s` 解锁后重试", locked.Path)
+	}
+
+	var policy *naming.PolicyError
+	if errors.As(err, &policy) {
+		if policy.Suggestion != "" {
+			return i18n.T("建议使用 %s，或使用 --no-verify 跳过检查", policy.Suggestion)
+		}
+		return i18n.T("修改分支名，或使用 --no-verify 跳过检查")
+	}
+
+	var conflict *git.ApplyConflictError
+	if errors.As(err, &conflict) {
+		if conflict.Merged {
+			return i18n.T("在目标 worktree 中解决冲突后用 git add 标记；源 worktree 中的修改没有改变")
+		}
+		return i18n.T("目标 worktree 没有被修改；先处理目标中的相关修改，或使用 --3way 以冲突标记的方式应用")
+	}
+
+	var notMerged *gwt.BranchNotMergedError
+	if errors.As(err, &notMerged) {
+		if notMerged.Remote != "" {
+			return i18n.T("确认远程分支上的提交不再需要后，使用 --force 删除")
+		}
+		return i18n.T("使用 --force 强制删除未合并的分支")
+	}
+
+	var ambiguous *git.AmbiguousTargetError
+	if errors.As(err, &ambiguous) {
+		return i18n.T("请使用完整的分支名或路径")
+	}
+
+	switch {
+	case errors.Is(err, git.ErrNotRepository):
+		return i18n.T("请在 Git 仓库中运行，或使用 `gwt clone <url>` 克隆仓库")
+	case errors.Is(err, git.ErrNotFound):
+		return i18n.T("运行 `gwt list` 查看所有 worktree，或使用 `gwt create <branch>` 创建")
+	case errors.Is(err, git.ErrBranchExists):
+		return i18n.T("去掉 --orphan 运行 `gwt create <branch>` 检出已有的分支，已在其他 worktree 检出时运行 `gwt switch <branch>`，或者换一个分支名")
+	case errors.Is(err, git.ErrInvalidReference):
+		return i18n.T("检查分支名是否正确，远程分支可以先运行 `git fetch`")
+	case errors.Is(err, git.ErrPathExists):
+		return i18n.T("使用 -f 强制创建，或用 --path 指定其他目录")
+	case errors.Is(err, git.ErrWorktreeDirty):
+		return i18n.T("提交或储藏修改，或使用 -f 强制删除（删除前会备份，可用 `gwt restore` 恢复）")
+	case errors.Is(err, ui.ErrInputRequired):
+		return i18n.T("使用 --yes 跳过确认，或在终端中交互运行")
+	case errors.Is(err, git.ErrLocked):
+		return i18n.T("运行 `gwt unlock <path>` 解锁后重试")
+	}
+
+	return ""
+}
+
+// printError 输出错误及其提示
+func printError(w io.Writer, err error) {
+	fmt.Fprintf(w, "Error: %v\n", err)
+	if hint := errorHint(err); hint != "" {
+		fmt.Fprintf(w, "💡 %s\n", color.BlueString(hint))
+	}
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/tinsfox/gwt/internal/git"
+	"github.com/tinsfox/gwt/pkg/gwt"
+)
+
+func TestErrorHint(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		// want 是提示中必须包含的内容，为空表示没有提示；notWant 是不能出现的内容
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "orphan branch exists",
+			err:     fmt.Errorf("%w: %s", git.ErrBranchExists, "feature/x"),
+			want:    []string{"--orphan", "`gwt create <branch>`", "`gwt switch <branch>`"},
+			notWant: []string{" -b"},
+		},
+		{
+			name: "branch checked out",
+			err:  &git.BranchCheckedOutError{Branch: "main", Path: "/repo"},
+			want: []string{"/repo", "`gwt switch main`"},
+		},
+		{
+			name: "locked",
+			err:  fmt.Errorf("删除 worktree 失败: %w", &git.LockedError{Path: "/repo.worktrees/feat"}),
+			want: []string{"`gwt unlock /repo.worktrees/feat`"},
+		},
+		{
+			name: "branch not merged",
+			err:  &gwt.BranchNotMergedError{Branch: "feat", Base: "main"},
+			want: []string{"--force"},
+		},
+		{
+			name: "not found",
+			err:  fmt.Errorf("%w: feat", git.ErrNotFound),
+			want: []string{"`gwt list`", "`gwt create <branch>`"},
+		},
+		{
+			name: "unknown",
+			err:  errors.New("boom"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hint := errorHint(tt.err)
+			if len(tt.want) == 0 && hint != "" {
+				t.Errorf("errorHint() = %q, want no hint", hint)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(hint, want) {
+					t.Errorf("errorHint() = %q, want it to contain %q", hint, want)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(hint, notWant) {
+					t.Errorf("errorHint() = %q, should not mention %q", hint, notWant)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tinsfox/gwt/internal/git"
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+var (
+	execParallel int
+	execJSON     bool
+	execShell    bool
+	execSelector worktreeSelector
+)
+
+// execCmd 在多个 worktree 中执行命令
+var execCmd = &cobra.Command{
+	Use:   "exec [path|branch...] -- <command> [args...]",
+	Short: "在多个 worktree 中执行命令",
+	Long: `在选中的每个 worktree 中执行同一条命令。
+
+不指定 worktree 也不使用选择条件时，会在所有 worktree 中执行。
+每行输出都带有 worktree 前缀，执行结束后汇总各 worktree 的退出状态；
+任何一个 worktree 执行失败时，gwt 以非零状态退出。`,
+	Example: `  # 在所有 worktree 中查看状态
+  gwt exec -- git status -s
+
+  # 在 feature 分支中并行运行测试
+  gwt exec --match 'feature/*' -j 4 -- go test ./...
+
+  # 使用 shell 执行复杂命令
+  gwt exec --shell -- 'npm ci && npm test'
+
+  # 以 JSON 格式输出结果
+  gwt exec --json -- git rev-parse HEAD`,
+	RunE: runExec,
+}
+
+// execResult 单个 worktree 的执行结果
+type execResult struct {
+	Path       string `json:"path"`
+	Branch     string `json:"branch"`
+	ExitCode   int    `json:"exit_code"`
+	DurationMs int64  `json:"duration_ms"`
+	Stdout     string `json:"stdout,omitempty"`
+	Stderr     string `json:"stderr,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+func init() {
+	rootCmd.AddCommand(execCmd)
+
+	execCmd.Flags().IntVarP(&execParallel, "parallel", "j", 1, "同时执行的 worktree 数量")
+	execCmd.Flags().BoolVar(&execJSON, "json", false, "以 JSON 格式输出结果")
+	execCmd.Flags().BoolVarP(&execShell, "shell", "s", false, "通过 $SHELL -c 执行命令")
+	execSelector.addFlags(execCmd)
+}
+
+func runExec(cmd *cobra.Command, args []string) error {
+	dash := cmd.ArgsLenAtDash()
+	if dash < 0 || dash == len(args) {
+		return i18n.Errorf("请在 -- 之后指定要执行的命令")
+	}
+	targets, command := args[:dash], args[dash:]
+
+	if execParallel < 1 {
+		return i18n.Errorf("--parallel 必须大于 0")
+	}
+
+	// 检查是否在 git 仓库中
+	repo, err := git.OpenRepository(".")
+	if err != nil {
+		return err
+	}
+
+	// 获取所有 worktree
+	worktrees, err := repo.GetWorktrees()
+	if err != nil {
+		return i18n.Errorf("获取 worktree 列表失败: %w", err)
+	}
+
+	selected, err := execSelector.selectWorktrees(repo, worktrees, targets)
+	if err != nil {
+		return err
+	}
+
+	if len(selected) == 0 {
+		if execJSON {
+			return printJSON([]execResult{})
+		}
+		if !quiet {
+			fmt.Println(i18n.T("没有匹配的 worktree"))
+		}
+		return nil
+	}
+
+	results := executeInWorktrees(selected, command)
+
+	failed := 0
+	for _, result := range results {
+		if result.ExitCode != 0 {
+			failed++
+		}
+	}
+
+	if execJSON {
+		if err := printJSON(results); err != nil {
+			return err
+		}
+	} else if !quiet {
+		printExecSummary(results)
+	}
+
+	if failed > 0 {
+		return i18n.Errorf("%d/%d 个 worktree 执行失败", failed, len(results))
+	}
+
+	return nil
+}
+
+// executeInWorktrees 按并行度在各 worktree 中执行命令，结果顺序与输入一致
+func executeInWorktrees(worktrees []git.WorktreeInfo, command []string) []execResult {
+	results := make([]execResult, len(worktrees))
+	sem := make(chan struct{}, execParallel)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for i, wt := range worktrees {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int, wt git.WorktreeInfo) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			results[i] = executeInWorktree(wt, command, &mu)
+		}(i, wt)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// executeInWorktree 在单个 worktree 中执行命令
+func executeInWorktree(wt git.WorktreeInfo, command []string, mu *sync.Mutex) execResult {
+	result := execResult{
+		Path:   wt.Path,
+		Branch: wt.Branch,
+	}
+
+	var c *exec.Cmd
+	if execShell {
+		shell := os.Getenv("SHELL")
+		if shell == "" {
+			shell = "/bin/sh"
+		}
+		c = exec.Command(shell, "-c", strings.Join(command, " "))
+	} else {
+		c = exec.Command(command[0], command[1:]...)
+	}
+	c.Dir = wt.Path
+
+	var stdout, stderr bytes.Buffer
+	if execJSON {
+		c.Stdout = &stdout
+		c.Stderr = &stderr
+	} else {
+		prefix := execPrefix(wt)
+		outWriter := &prefixWriter{prefix: prefix, out: os.Stdout, mu: mu}
+		errWriter := &prefixWriter{prefix: prefix, out: os.Stderr, mu: mu}
+		defer outWriter.Flush()
+		defer errWriter.Flush()
+		c.Stdout = outWriter
+		c.Stderr = errWriter
+	}
+
+	start := time.Now()
+	err := c.Run()
+	result.DurationMs = time.Since(start).Milliseconds()
+	result.Stdout = stdout.String()
+	result.Stderr = stderr.String()
+
+	if err != nil {
+		result.ExitCode = -1
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			result.ExitCode = exitErr.ExitCode()
+		}
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+// execPrefix 生成输出前缀
+func execPrefix(wt git.WorktreeInfo) string {
+	name := wt.Branch
+	if name == "" {
+		name = wt.Path
+	}
+	return color.CyanString("[%s]", name) + " "
+}
+
+// printExecSummary 打印执行结果汇总
+func printExecSummary(results []execResult) {
+	succeeded := 0
+	fmt.Println()
+	fmt.Println(i18n.T("执行结果:"))
+	for _, result := range results {
+		name := result.Branch
+		if name == "" {
+			name = result.Path
+		}
+
+		duration := time.Duration(result.DurationMs) * time.Millisecond
+		if result.ExitCode == 0 {
+			succeeded++
+			fmt.Printf("  ✅ %s (%s)\n", color.CyanString(name), duration)
+		} else {
+			i18n.Printf("  ❌ %s (%s) 退出码 %d\n", color.CyanString(name), duration, result.ExitCode)
+		}
+	}
+	i18n.Printf("成功 %s，失败 %s\n",
+		color.GreenString("%d", succeeded),
+		color.RedString("%d", len(results)-succeeded))
+}
+
+// printJSON 以缩进 JSON 格式输出
+func printJSON(v interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// prefixWriter 为每行输出加上前缀，多个 writer 共享同一把锁以避免行交错
+type prefixWriter struct {
+	prefix string
+	out    io.Writer
+	mu     *sync.Mutex
+	buf    []byte
+}
+
+// Write 缓冲输入，并按完整的行输出
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		idx := bytes.IndexByte(w.buf, '\n')
+		if idx < 0 {
+			break
+		}
+		w.writeLine(w.buf[:idx+1])
+		w.buf = w.buf[idx+1:]
+	}
+	return len(p), nil
+}
+
+// Flush 输出剩余不完整的行
+func (w *prefixWriter) Flush() {
+	if len(w.buf) > 0 {
+		w.writeLine(append(w.buf, '\n'))
+		w.buf = nil
+	}
+}
+
+func (w *prefixWriter) writeLine(line []byte) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	io.WriteString(w.out, w.prefix)
+	w.out.Write(line)
+}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"path"
+	"strings"
+	"unicode"
+
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+// worktreeFilter 判断 worktree 是否符合 gwt list 的筛选条件
+type worktreeFilter func(e *listEntry) bool
+
+// filterConditions 是 --filter 表达式中可以使用的条件
+var filterConditions = map[string]worktreeFilter{
+	"dirty":    func(e *listEntry) bool { return e.IsDirty },
+	"clean":    func(e *listEntry) bool { return !e.IsDirty && e.Prunable == "" },
+	"main":     func(e *listEntry) bool { return e.IsMain },
+	"locked":   func(e *listEntry) bool { return e.IsLocked },
+	"detached": func(e *listEntry) bool { return e.Branch == "" && e.Prunable == "" },
+	"sparse":   func(e *listEntry) bool { return e.Sparse },
+	"scratch":  func(e *listEntry) bool { return e.IsScratch },
+	"prunable": func(e *listEntry) bool { return e.Prunable != "" },
+	"ahead":    func(e *listEntry) bool { return e.Ahead() > 0 },
+	"behind":   func(e *listEntry) bool { return e.Behind() > 0 },
+}
+
+// parseFilter 解析 --filter 表达式，如 dirty && !main、(ahead || behind) && tag:perf
+//
+// 支持 &&、||、! 和括号，条件见 filterConditions，另外支持 tag:<标签> 和 branch:<通配符>。
+func parseFilter(expr string) (worktreeFilter, error) {
+	p := &filterParser{expr: expr, tokens: tokenizeFilter(expr)}
+	if len(p.tokens) == 0 {
+		return nil, i18n.Errorf("筛选表达式为空")
+	}
+
+	filter, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, p.errorf(p.tokens[p.pos])
+	}
+	return filter, nil
+}
+
+// filterParser 是筛选表达式的递归下降解析器
+type filterParser struct {
+	expr   string
+	tokens []string
+	pos    int
+}
+
+func (p *filterParser) next() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	token := p.tokens[p.pos]
+	p.pos++
+	return token
+}
+
+func (p *filterParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) errorf(token string) error {
+	if token == "" {
+		return i18n.Errorf("筛选表达式不完整: %s", p.expr)
+	}
+	return i18n.Errorf("筛选表达式中有无效的内容 %q: %s", token, p.expr)
+}
+
+func (p *filterParser) parseOr() (worktreeFilter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "||" {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(e *listEntry) bool { return l(e) || right(e) }
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (worktreeFilter, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "&&" {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(e *listEntry) bool { return l(e) && right(e) }
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (worktreeFilter, error) {
+	token := p.next()
+	switch token {
+	case "!":
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(e *listEntry) bool { return !inner(e) }, nil
+	case "(":
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing != ")" {
+			return nil, p.errorf(closing)
+		}
+		return inner, nil
+	}
+
+	if tag, ok := strings.CutPrefix(token, "tag:"); ok && tag != "" {
+		return func(e *listEntry) bool { return e.Metadata.HasTag(tag) }, nil
+	}
+	if pattern, ok := strings.CutPrefix(token, "branch:"); ok && pattern != "" {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, i18n.Errorf("无效的通配符: %s", pattern)
+		}
+		return func(e *listEntry) bool { return matchBranch(e.Branch, pattern) }, nil
+	}
+	if filter, ok := filterConditions[token]; ok {
+		return filter, nil
+	}
+	return nil, p.errorf(token)
+}
+
+// tokenizeFilter 把筛选表达式拆分为运算符、括号和条件
+func tokenizeFilter(expr string) []string {
+	var tokens []string
+	for i := 0; i < len(expr); {
+		switch c := expr[i]; {
+		case c == ' ' || c == '\t':
+			i++
+		case strings.HasPrefix(expr[i:], "&&"), strings.HasPrefix(expr[i:], "||"):
+			tokens = append(tokens, expr[i:i+2])
+			i += 2
+		case c == '!' || c == '(' || c == ')':
+			tokens = append(tokens, string(c))
+			i++
+		default:
+			j := strings.IndexFunc(expr[i:], func(r rune) bool {
+				return unicode.IsSpace(r) || strings.ContainsRune("!()&|", r)
+			})
+			if j < 0 {
+				j = len(expr) - i
+			}
+			if j == 0 {
+				// 单独的 & 或 |
+				j = 1
+			}
+			tokens = append(tokens, expr[i:i+j])
+			i += j
+		}
+	}
+	return tokens
+}
+
+// matchBranch 检查分支名是否匹配通配符，分离 HEAD 的 worktree 不匹配任何通配符
+func matchBranch(branch, pattern string) bool {
+	if branch == "" {
+		return false
+	}
+	ok, _ := path.Match(pattern, branch)
+	return ok
+}
@@ -1,22 +1,38 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"text/tabwriter"
+	"text/template"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 	"github.com/tinsfox/gwt/internal/git"
+	"github.com/tinsfox/gwt/internal/i18n"
 	"github.com/tinsfox/gwt/internal/ui"
+	"github.com/tinsfox/gwt/pkg/gwt"
 )
 
 var (
-	listAll    bool
-	listJSON   bool
-	listFormat string
+	listAll        bool
+	listJSON       bool
+	listFormat     string
+	listTags       []string
+	listSort       string
+	listFilter     string
+	listDirty      bool
+	listLocked     bool
+	listBranchGlob string
+	listColumns    []string
 )
 
 // listCmd 列出所有 worktree
@@ -24,59 +40,278 @@ var listCmd = &cobra.Command{
 	Use:     "list",
 	Aliases: []string{"ls"},
 	Short:   "列出所有 Git worktree",
-	Long:    `显示当前仓库中所有的 worktree，包括路径、分支、状态等信息。`,
-	RunE:    runList,
+	Long: `显示当前仓库中所有的 worktree，包括路径、分支、状态等信息。
+
+--filter 使用 &&、||、! 和括号组合条件：dirty、clean、main、locked、detached、sparse、
+scratch、prunable、ahead、behind，以及 tag:<标签> 和 branch:<通配符>。
+
+--columns 选择表格的列：path、branch、status、commit、description、tags、age、created、
+accessed、committed、locked、upstream、ahead、behind。
+
+--format 除了 table、simple、json 之外，也可以是 Go 模板，每个 worktree 输出一行，
+可以使用 .Path、.RelPath、.Branch、.IsDirty、.Upstream、.Ahead、.Behind、.CreatedAt 等字段，
+以及 age 函数（如 {{age .CreatedAt}}）。`,
+	Example: `  # 有修改的非主工作区 worktree
+  gwt list --filter 'dirty && !main'
+
+  # feature 分支，按最近活动排序
+  gwt list --branch-glob 'feature/*' --sort activity
+
+  # 选择列
+  gwt list --columns path,branch,ahead,behind,age
+
+  # 自定义输出
+  gwt list --format '{{.Branch}}\t{{.Path}}'`,
+	RunE: runList,
 }
 
 func init() {
 	rootCmd.AddCommand(listCmd)
 
-	listCmd.Flags().BoolVarP(&listAll, "all", "a", false, "显示所有 worktree（包括已删除的）")
+	listCmd.Flags().BoolVarP(&listAll, "all", "a", false, "显示所有 worktree（包括目录已删除、可以清理的）")
 	listCmd.Flags().BoolVar(&listJSON, "json", false, "以 JSON 格式输出")
-	listCmd.Flags().StringVarP(&listFormat, "format", "f", "table", "输出格式: table, simple, json")
+	listCmd.Flags().StringVarP(&listFormat, "format", "f", "table", "输出格式: table, simple, json，或 Go 模板")
+	listCmd.Flags().StringVar(&listSort, "sort", "", "排序方式: created（创建时间，从新到旧）, activity（最近活动，从新到旧）, branch, path")
+	listCmd.Flags().StringSliceVar(&listTags, "tag", nil, "只显示带有这些标签的 worktree（可重复或用逗号分隔）")
+	listCmd.Flags().StringVar(&listFilter, "filter", "", "只显示符合表达式的 worktree，如 'dirty && !main'")
+	listCmd.Flags().BoolVar(&listDirty, "dirty", false, "只显示有修改的 worktree")
+	listCmd.Flags().BoolVar(&listLocked, "locked", false, "只显示锁定的 worktree")
+	listCmd.Flags().StringVar(&listBranchGlob, "branch-glob", "", "只显示分支名匹配通配符的 worktree，如 'feature/*'")
+	listCmd.Flags().StringSliceVar(&listColumns, "columns", nil, "表格中显示的列，用逗号分隔（参见 gwt list --help）")
 }
 
-func runList(cmd *cobra.Command, args []string) error {
-	// 检查是否在 git 仓库中
-	repo, err := git.OpenRepository(".")
-	if err != nil {
-		return fmt.Errorf("不是 Git 仓库: %w", err)
+// listEntry 是 gwt list 中的一行
+type listEntry struct {
+	git.WorktreeInfo
+	// RelPath 是相对于当前目录的路径
+	RelPath string
+
+	repo     *git.Repository
+	upstream *listUpstream
+}
+
+// listUpstream 是分支的上游及领先、落后的提交数，只在用到时获取
+type listUpstream struct {
+	name          string
+	ahead, behind int
+}
+
+func (e *listEntry) loadUpstream() *listUpstream {
+	if e.upstream != nil {
+		return e.upstream
 	}
 
+	e.upstream = &listUpstream{}
+	if e.Prunable != "" || e.Branch == "" {
+		return e.upstream
+	}
+	if name, _ := e.repo.Upstream(e.Path); name != "" {
+		e.upstream.name = name
+		e.upstream.ahead, e.upstream.behind, _ = e.repo.AheadBehind(e.Path, name)
+	}
+	return e.upstream
+}
+
+// Upstream 返回分支的上游分支，没有上游时为空
+func (e *listEntry) Upstream() string { return e.loadUpstream().name }
+
+// Ahead 返回领先上游的提交数
+func (e *listEntry) Ahead() int { return e.loadUpstream().ahead }
+
+// Behind 返回落后上游的提交数
+func (e *listEntry) Behind() int { return e.loadUpstream().behind }
+
+func runList(cmd *cobra.Command, args []string) error {
+	client := newClient(cmd)
+
 	// 获取 worktree 列表
-	worktrees, err := repo.GetWorktrees()
+	worktrees, err := client.List(cmd.Context())
 	if err != nil {
-		return fmt.Errorf("获取 worktree 列表失败: %w", err)
+		return err
+	}
+
+	if listAll {
+		prunable, err := client.Prune(cmd.Context(), gwt.PruneOptions{DryRun: true})
+		if err != nil {
+			return err
+		}
+		worktrees = append(worktrees, prunable...)
 	}
 
 	if len(worktrees) == 0 {
-		fmt.Println("当前仓库没有 worktree")
+		fmt.Println(i18n.T("当前仓库没有 worktree"))
 		return nil
 	}
 
+	repo, err := git.OpenRepository(".")
+	if err != nil {
+		return err
+	}
+	repo = repo.WithContext(cmd.Context())
+
+	entries := make([]*listEntry, len(worktrees))
+	for i, wt := range worktrees {
+		entries[i] = &listEntry{WorktreeInfo: wt, RelPath: displayPath(wt.Path), repo: repo}
+	}
+
+	filters, err := listFilters()
+	if err != nil {
+		return err
+	}
+	entries = filterEntries(entries, filters)
+
+	if listSort != "" {
+		if err := sortEntries(entries, listSort); err != nil {
+			return err
+		}
+	}
+
+	if len(listColumns) > 0 && listFormat != "table" {
+		return i18n.Errorf("--columns 只能用于表格输出")
+	}
+
 	// 根据格式输出
-	switch listFormat {
-	case "json":
-		return outputJSON(worktrees)
-	case "simple":
-		return outputSimple(worktrees)
-	case "table":
-		return outputTable(worktrees)
+	switch {
+	case listFormat == "json":
+		return outputJSON(entries)
+	case listFormat == "simple":
+		return outputSimple(entries)
+	case listFormat == "table":
+		if len(entries) == 0 {
+			fmt.Println(i18n.T("没有符合条件的 worktree"))
+			return nil
+		}
+		return outputTable(entries)
+	case strings.Contains(listFormat, "{{"):
+		return outputTemplate(entries, listFormat)
 	default:
-		return fmt.Errorf("不支持的输出格式: %s", listFormat)
+		return i18n.Errorf("不支持的输出格式: %s", listFormat)
 	}
 }
 
-// outputTable 以表格形式输出
-func outputTable(worktrees []git.WorktreeInfo) error {
-	// 创建表格
-	table := tablewriter.NewWriter(os.Stdout)
+// listFilters 根据筛选选项生成筛选条件，worktree 需要满足所有条件
+func listFilters() ([]worktreeFilter, error) {
+	var filters []worktreeFilter
+	if listFilter != "" {
+		filter, err := parseFilter(listFilter)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+	if listDirty {
+		filters = append(filters, filterConditions["dirty"])
+	}
+	if listLocked {
+		filters = append(filters, filterConditions["locked"])
+	}
+	if listBranchGlob != "" {
+		if _, err := path.Match(listBranchGlob, ""); err != nil {
+			return nil, i18n.Errorf("无效的通配符: %s", listBranchGlob)
+		}
+		filters = append(filters, func(e *listEntry) bool { return matchBranch(e.Branch, listBranchGlob) })
+	}
+	for _, tag := range listTags {
+		tag := tag
+		filters = append(filters, func(e *listEntry) bool { return e.Metadata.HasTag(tag) })
+	}
+	return filters, nil
+}
 
-	// 设置表头
-	headers := []string{"路径", "分支", "状态", "上次提交"}
+// filterEntries 返回满足所有筛选条件的 worktree
+func filterEntries(entries []*listEntry, filters []worktreeFilter) []*listEntry {
+	var result []*listEntry
+	for _, e := range entries {
+		matched := true
+		for _, filter := range filters {
+			if !filter(e) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// displayPath 返回相对于当前目录的路径，无法计算时返回原路径
+func displayPath(p string) string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return p
+	}
+	rel, err := filepath.Rel(cwd, p)
+	if err != nil {
+		return p
+	}
+	return rel
+}
+
+// listColumn 是 gwt list 表格中的一列
+type listColumn struct {
+	header string
+	value  func(e *listEntry) string
+}
+
+// tableColumns 返回表格可以显示的列，表头在调用时翻译
+func tableColumns() map[string]listColumn {
+	return map[string]listColumn{
+		"path":        {i18n.T("路径"), func(e *listEntry) string { return ui.ColorPath(e.RelPath) }},
+		"branch":      {i18n.T("分支"), func(e *listEntry) string { return ui.ColorBranch(formatBranch(e)) }},
+		"status":      {i18n.T("状态"), formatStatus},
+		"commit":      {i18n.T("上次提交"), func(e *listEntry) string { return formatCommitInfo(e.LastCommit) }},
+		"description": {i18n.T("说明"), func(e *listEntry) string { return formatMetadata(e.Metadata) }},
+		"tags":        {i18n.T("标签"), func(e *listEntry) string { return strings.Join(e.Metadata.Tags, ", ") }},
+		"age":         {i18n.T("年龄"), func(e *listEntry) string { return formatAge(e.CreatedAt) }},
+		"created":     {i18n.T("创建时间"), formatCreated},
+		"accessed":    {i18n.T("最近访问"), func(e *listEntry) string { return formatAge(e.LastAccessed) }},
+		"committed":   {i18n.T("距上次提交"), func(e *listEntry) string { return formatAge(e.LastCommit.Date) }},
+		"locked":      {i18n.T("锁定状态"), func(e *listEntry) string { return getLockStatus(e.IsLocked) }},
+		"upstream":    {i18n.T("上游"), func(e *listEntry) string { return e.Upstream() }},
+		"ahead":       {i18n.T("领先"), func(e *listEntry) string { return formatCount(e.Ahead()) }},
+		"behind":      {i18n.T("落后"), func(e *listEntry) string { return formatCount(e.Behind()) }},
+	}
+}
+
+// defaultColumns 返回默认显示的列：有说明或标签时显示说明列，详细模式下显示时间和锁定状态
+func defaultColumns(entries []*listEntry) []string {
+	columns := []string{"path", "branch", "status", "commit"}
+	for _, e := range entries {
+		if formatMetadata(e.Metadata) != "" {
+			columns = append(columns, "description")
+			break
+		}
+	}
+	columns = append(columns, "age")
 	if verbose {
-		headers = append(headers, "创建时间", "锁定状态")
+		columns = append(columns, "created", "accessed", "committed", "locked")
 	}
+	return columns
+}
+
+// outputTable 以表格形式输出
+func outputTable(entries []*listEntry) error {
+	names := listColumns
+	if len(names) == 0 {
+		names = defaultColumns(entries)
+	}
+
+	available := tableColumns()
+	columns := make([]listColumn, 0, len(names))
+	headers := make([]string, 0, len(names))
+	for _, name := range names {
+		column, ok := available[strings.TrimSpace(name)]
+		if !ok {
+			return i18n.Errorf("未知的列: %s（参见 gwt list --help）", name)
+		}
+		columns = append(columns, column)
+		headers = append(headers, column.header)
+	}
+
+	// 创建表格
+	table := tablewriter.NewWriter(os.Stdout)
 	table.SetHeader(headers)
 
 	// 设置样式
@@ -89,93 +324,187 @@ func outputTable(worktrees []git.WorktreeInfo) error {
 	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
 
 	// 添加数据
-	for _, wt := range worktrees {
-		row := make([]string, 0)
-
-		// 路径（相对路径）
-		relPath, _ := filepath.Rel(".", wt.Path)
-		if relPath == "" {
-			relPath = "."
+	for _, e := range entries {
+		row := make([]string, 0, len(columns))
+		for _, column := range columns {
+			row = append(row, column.value(e))
 		}
-		row = append(row, ui.ColorPath(relPath))
+		table.Append(row)
+	}
 
-		// 分支
-		branch := wt.Branch
-		if branch == "" {
-			branch = "(分离 HEAD)"
+	table.Render()
+	return nil
+}
+
+// outputTemplate 用 Go 模板输出，每个 worktree 一行
+//
+// 模板中的 \t 和 \n 按制表符和换行处理，方便在 shell 中书写。
+func outputTemplate(entries []*listEntry, format string) error {
+	format = strings.NewReplacer(`\t`, "\t", `\n`, "\n").Replace(format)
+	tmpl, err := template.New("list").Funcs(template.FuncMap{
+		"age":  formatAge,
+		"join": strings.Join,
+	}).Parse(format)
+	if err != nil {
+		return i18n.Errorf("无效的输出模板: %w", err)
+	}
+
+	w := bufio.NewWriter(os.Stdout)
+	for _, e := range entries {
+		if err := tmpl.Execute(w, e); err != nil {
+			return i18n.Errorf("执行输出模板失败: %w", err)
 		}
-		row = append(row, ui.ColorBranch(branch))
+		fmt.Fprintln(w)
+	}
+	return w.Flush()
+}
 
-		// 状态
-		status := getWorktreeStatus(&wt)
-		row = append(row, status)
+// formatBranch 返回分支名，分离 HEAD 时返回提交的描述
+func formatBranch(e *listEntry) string {
+	switch {
+	case e.Branch != "":
+		return e.Branch
+	case e.Prunable != "":
+		return "-"
+	default:
+		return i18n.T("(分离 HEAD: %s)", e.Describe)
+	}
+}
 
-		// 上次提交
-		commitInfo := formatCommitInfo(wt.LastCommit)
-		row = append(row, commitInfo)
+// formatStatus 返回状态列，附加稀疏检出和临时 worktree 标记
+func formatStatus(e *listEntry) string {
+	status := getWorktreeStatus(&e.WorktreeInfo)
+	if e.Sparse {
+		status += " " + color.BlueString(i18n.T("稀疏"))
+	}
+	if e.IsScratch {
+		status += " " + color.HiBlackString(i18n.T("临时"))
+	}
+	return status
+}
 
-		// 详细信息
-		if verbose {
-			row = append(row, wt.CreatedAt.Format("2006-01-02 15:04"))
-			row = append(row, getLockStatus(wt.IsLocked))
-		}
+// formatCreated 返回创建时间，未知时显示为 -
+func formatCreated(e *listEntry) string {
+	if e.CreatedAt.IsZero() {
+		return "-"
+	}
+	return e.CreatedAt.Format("2006-01-02 15:04")
+}
 
-		table.Append(row)
+// formatCount 格式化提交数，0 显示为空
+func formatCount(n int) string {
+	if n == 0 {
+		return ""
 	}
+	return strconv.Itoa(n)
+}
 
-	table.Render()
+// sortEntries 按 by 排序 worktree，相同时保持原有顺序
+func sortEntries(entries []*listEntry, by string) error {
+	var less func(a, b *listEntry) bool
+	switch by {
+	case "created":
+		less = func(a, b *listEntry) bool { return a.CreatedAt.After(b.CreatedAt) }
+	case "activity":
+		less = func(a, b *listEntry) bool { return a.LastActivity().After(b.LastActivity()) }
+	case "branch":
+		less = func(a, b *listEntry) bool { return a.Branch < b.Branch }
+	case "path":
+		less = func(a, b *listEntry) bool { return a.Path < b.Path }
+	default:
+		return i18n.Errorf("不支持的排序方式: %s（可选: created, activity, branch, path）", by)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return less(entries[i], entries[j])
+	})
 	return nil
 }
 
+// formatAge 把时间格式化为距今的简短时长，如 45m、3h、3d、2w、5mo、1y，零值显示为 -
+func formatAge(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+
+	d := time.Since(t)
+	if d < 0 {
+		d = 0
+	}
+	days := int(d.Hours() / 24)
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	case days < 14:
+		return fmt.Sprintf("%dd", days)
+	case days < 60:
+		return fmt.Sprintf("%dw", days/7)
+	case days < 365:
+		return fmt.Sprintf("%dmo", days/30)
+	default:
+		return fmt.Sprintf("%dy", days/365)
+	}
+}
+
 // outputSimple 以简单格式输出
-func outputSimple(worktrees []git.WorktreeInfo) error {
+func outputSimple(entries []*listEntry) error {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 
-	for _, wt := range worktrees {
-		relPath, _ := filepath.Rel(".", wt.Path)
-		if relPath == "" {
-			relPath = "."
-		}
-
-		branch := wt.Branch
+	for _, e := range entries {
+		branch := e.Branch
 		if branch == "" {
-			branch = "(detached)"
+			branch = "(detached:" + e.Describe + ")"
 		}
 
-		status := getSimpleStatus(&wt)
+		status := getSimpleStatus(&e.WorktreeInfo)
+		if e.Sparse {
+			status += ",sparse"
+		}
+		if e.IsScratch {
+			status += ",scratch"
+		}
 
-		fmt.Fprintf(w, "%s\t%s\t%s\n", relPath, branch, status)
+		fmt.Fprintf(w, "%s\t%s\t%s\n", e.RelPath, branch, status)
 	}
 
 	return w.Flush()
 }
 
 // outputJSON 以 JSON 格式输出
-func outputJSON(worktrees []git.WorktreeInfo) error {
+func outputJSON(entries []*listEntry) error {
 	// 这里需要实现 JSON 输出
 	// 为了简化，先用简单格式代替
-	return outputSimple(worktrees)
+	return outputSimple(entries)
 }
 
 // getWorktreeStatus 获取 worktree 状态
 func getWorktreeStatus(wt *git.WorktreeInfo) string {
+	if wt.Prunable != "" {
+		return ui.ColorError(i18n.T("可清理"))
+	}
+
 	if wt.IsLocked {
-		return ui.ColorWarning("已锁定")
+		return ui.ColorWarning(i18n.T("已锁定"))
 	}
 
 	if wt.IsDirty {
-		return ui.ColorError("已修改")
+		return ui.ColorError(i18n.T("已修改"))
 	}
 
 	if wt.IsMain {
-		return ui.ColorSuccess("主工作区")
+		return ui.ColorSuccess(i18n.T("主工作区"))
 	}
 
-	return ui.ColorSuccess("清洁")
+	return ui.ColorSuccess(i18n.T("清洁"))
 }
 
 // getSimpleStatus 获取简化状态
 func getSimpleStatus(wt *git.WorktreeInfo) string {
+	if wt.Prunable != "" {
+		return "prunable"
+	}
 	if wt.IsLocked {
 		return "locked"
 	}
@@ -191,15 +520,15 @@ func getSimpleStatus(wt *git.WorktreeInfo) string {
 // getLockStatus 获取锁定状态
 func getLockStatus(locked bool) string {
 	if locked {
-		return color.YellowString("已锁定")
+		return color.YellowString(i18n.T("已锁定"))
 	}
-	return color.GreenString("未锁定")
+	return color.GreenString(i18n.T("未锁定"))
 }
 
 // formatCommitInfo 格式化提交信息
 func formatCommitInfo(commit git.CommitInfo) string {
 	if commit.Hash == "" {
-		return "无提交记录"
+		return i18n.T("无提交记录")
 	}
 
 	shortHash := commit.Hash[:7]
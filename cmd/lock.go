@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+var (
+	lockReason string
+)
+
+// lockCmd 锁定 worktree
+var lockCmd = &cobra.Command{
+	Use:   "lock [path|branch]",
+	Short: "锁定 worktree，防止被删除或清理",
+	Long: `锁定 worktree。被锁定的 worktree 不会被 git worktree prune 清理，
+也不能直接删除，适合放在可移动磁盘或网络共享上的 worktree。
+
+不指定 worktree 时锁定当前所在的 worktree。`,
+	Example: `  # 锁定并注明原因
+  gwt lock feature/login --reason "在移动硬盘上"
+
+  # 解锁
+  gwt unlock feature/login`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runLock,
+}
+
+// unlockCmd 解锁 worktree
+var unlockCmd = &cobra.Command{
+	Use:   "unlock [path|branch]",
+	Short: "解锁 worktree",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runUnlock,
+}
+
+func init() {
+	rootCmd.AddCommand(lockCmd)
+	rootCmd.AddCommand(unlockCmd)
+
+	lockCmd.Flags().StringVar(&lockReason, "reason", "", "锁定原因")
+}
+
+func runLock(cmd *cobra.Command, args []string) error {
+	target := ""
+	if len(args) > 0 {
+		target = args[0]
+	}
+
+	wt, err := newClient(cmd).Lock(cmd.Context(), target, lockReason)
+	if err != nil {
+		return err
+	}
+
+	if !quiet {
+		fmt.Printf("🔒 %s %s\n", color.GreenString(i18n.T("已锁定")), wt.Path)
+	}
+
+	return nil
+}
+
+func runUnlock(cmd *cobra.Command, args []string) error {
+	target := ""
+	if len(args) > 0 {
+		target = args[0]
+	}
+
+	wt, err := newClient(cmd).Unlock(cmd.Context(), target)
+	if err != nil {
+		return err
+	}
+
+	if !quiet {
+		fmt.Printf("🔓 %s %s\n", color.GreenString(i18n.T("已解锁")), wt.Path)
+	}
+
+	return nil
+}
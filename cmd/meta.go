@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tinsfox/gwt/internal/i18n"
+	"github.com/tinsfox/gwt/pkg/gwt"
+)
+
+var (
+	metaDescription string
+	metaTicket      string
+	metaOwner       string
+	metaTags        []string
+	metaUntags      []string
+)
+
+// metaCmd 查看或修改 worktree 的元数据
+var metaCmd = &cobra.Command{
+	Use:   "meta [path|branch]",
+	Short: "查看或修改 worktree 的说明、标签、工单和负责人",
+	Long: `为 worktree 记录说明、标签、关联的工单和负责人，方便在 worktree 很多时记住每个的用途。
+
+元数据保存在公共 git 目录中 worktree 的管理目录里，移动 worktree 后仍然保留，
+删除 worktree 时随之删除。gwt list 会显示说明和标签，并可以用 --tag 筛选。
+
+不带修改选项时显示元数据；不指定 worktree 时使用当前所在的 worktree。`,
+	Example: `  # 查看当前 worktree 的元数据
+  gwt meta
+
+  # 设置说明和工单，添加标签
+  gwt meta feature/login --description "登录页改版" --ticket ISSUE-123 --tag ui
+
+  # 移除标签、清除负责人
+  gwt meta feature/login --untag ui --owner ""`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runMeta,
+}
+
+func init() {
+	rootCmd.AddCommand(metaCmd)
+
+	metaCmd.Flags().StringVar(&metaDescription, "description", "", "设置说明（空字符串清除）")
+	metaCmd.Flags().StringVar(&metaTicket, "ticket", "", "设置关联的工单（空字符串清除）")
+	metaCmd.Flags().StringVar(&metaOwner, "owner", "", "设置负责人（空字符串清除）")
+	metaCmd.Flags().StringSliceVar(&metaTags, "tag", nil, "添加标签（可重复或用逗号分隔）")
+	metaCmd.Flags().StringSliceVar(&metaUntags, "untag", nil, "移除标签（可重复或用逗号分隔）")
+}
+
+func runMeta(cmd *cobra.Command, args []string) error {
+	target := ""
+	if len(args) > 0 {
+		target = args[0]
+	}
+
+	client := newClient(cmd)
+	flags := cmd.Flags()
+
+	var update gwt.MetadataUpdate
+	if flags.Changed("description") {
+		update.Description = &metaDescription
+	}
+	if flags.Changed("ticket") {
+		update.Ticket = &metaTicket
+	}
+	if flags.Changed("owner") {
+		update.Owner = &metaOwner
+	}
+	update.AddTags = metaTags
+	update.RemoveTags = metaUntags
+
+	changed := update.Description != nil || update.Ticket != nil || update.Owner != nil ||
+		len(update.AddTags) > 0 || len(update.RemoveTags) > 0
+
+	var wt *gwt.Worktree
+	var err error
+	if changed {
+		wt, err = client.UpdateMetadata(cmd.Context(), target, update)
+	} else {
+		wt, err = client.Find(cmd.Context(), target)
+	}
+	if err != nil {
+		return err
+	}
+
+	if quiet {
+		return nil
+	}
+
+	i18n.Printf("路径: %s\n", color.YellowString(wt.Path))
+	printMetadata(wt.Metadata)
+	printWorktreeTimes(*wt)
+	if wt.Metadata.IsEmpty() {
+		fmt.Println(color.HiBlackString(i18n.T("没有记录说明和标签，使用 --description、--tag 等选项添加")))
+	}
+	return nil
+}
+
+// printMetadata 显示 worktree 的元数据，省略没有设置的字段
+func printMetadata(meta gwt.Metadata) {
+	if meta.Description != "" {
+		i18n.Printf("说明: %s\n", meta.Description)
+	}
+	if len(meta.Tags) > 0 {
+		i18n.Printf("标签: %s\n", color.MagentaString(strings.Join(meta.Tags, ", ")))
+	}
+	if meta.Ticket != "" {
+		i18n.Printf("工单: %s\n", color.CyanString(meta.Ticket))
+	}
+	if meta.Owner != "" {
+		i18n.Printf("负责人: %s\n", meta.Owner)
+	}
+}
+
+// printWorktreeTimes 显示 worktree 的创建时间和最近访问时间
+func printWorktreeTimes(wt gwt.Worktree) {
+	if !wt.CreatedAt.IsZero() {
+		i18n.Printf("创建时间: %s（%s 前）\n", wt.CreatedAt.Format("2006-01-02 15:04"), formatAge(wt.CreatedAt))
+	}
+	if !wt.LastAccessed.IsZero() {
+		i18n.Printf("最近访问: %s（%s 前）\n", wt.LastAccessed.Format("2006-01-02 15:04"), formatAge(wt.LastAccessed))
+	}
+}
+
+// formatMetadata 把说明、工单和标签合成一列，用于 gwt list
+func formatMetadata(meta gwt.Metadata) string {
+	var parts []string
+	if meta.Description != "" {
+		parts = append(parts, meta.Description)
+	}
+	if meta.Ticket != "" {
+		parts = append(parts, "["+meta.Ticket+"]")
+	}
+	for _, tag := range meta.Tags {
+		parts = append(parts, color.MagentaString("#"+tag))
+	}
+	return strings.Join(parts, " ")
+}
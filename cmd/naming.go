@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"github.com/tinsfox/gwt/internal/git"
+	"github.com/tinsfox/gwt/internal/i18n"
+	"github.com/tinsfox/gwt/internal/naming"
+)
+
+// loadBranchPolicy 读取项目配置和用户配置中的 branch_policy
+func loadBranchPolicy(repo *git.Repository) (*naming.Policy, error) {
+	var policy naming.Policy
+	if err := unmarshalConfig(repo, "branch_policy", &policy); err != nil {
+		return nil, err
+	}
+	if err := policy.Compile(); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// verifyBranchName 在创建新分支前检查分支名是否合法并符合命名规则
+//
+// 已存在的分支不再检查，不符合时返回带有建议名称的 *naming.PolicyError。
+func verifyBranchName(repo *git.Repository, branch string) error {
+	exists, err := repo.BranchExists(branch)
+	if err != nil {
+		return i18n.Errorf("检查分支失败: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	policy, err := loadBranchPolicy(repo)
+	if err != nil {
+		return err
+	}
+
+	violations := policy.Validate(branch)
+	if err := repo.CheckBranchName(branch); err != nil {
+		violations = append([]string{i18n.T("不是合法的 git 分支名（git check-ref-format）")}, violations...)
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return &naming.PolicyError{
+		Branch:     branch,
+		Violations: violations,
+		Suggestion: policy.Suggest(branch),
+	}
+}
@@ -5,7 +5,8 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
-	"github.com/tinsfox/gwt/internal/git"
+	"github.com/tinsfox/gwt/internal/i18n"
+	"github.com/tinsfox/gwt/pkg/gwt"
 )
 
 // pruneCmd 清理无效的 worktree
@@ -32,73 +33,56 @@ func init() {
 }
 
 func runPrune(cmd *cobra.Command, args []string) error {
-	// 检查是否在 git 仓库中
-	repo, err := git.OpenRepository(".")
-	if err != nil {
-		return fmt.Errorf("不是 Git 仓库: %w", err)
-	}
+	client := newClient(cmd)
 
-	// 获取所有 worktree
-	worktrees, err := repo.GetWorktrees()
+	// 获取目录已不存在的 worktree
+	prunable, err := client.Prune(cmd.Context(), gwt.PruneOptions{DryRun: true})
 	if err != nil {
-		return fmt.Errorf("获取 worktree 列表失败: %w", err)
+		return i18n.Errorf("获取 worktree 列表失败: %w", err)
 	}
 
-	if len(worktrees) == 0 {
+	if len(prunable) == 0 {
 		if !quiet {
-			fmt.Println("没有 worktree 需要清理")
+			fmt.Println(i18n.T("没有无效的 worktree"))
 		}
 		return nil
 	}
 
-	// 检查每个 worktree 的有效性
-	var invalidWorktrees []git.WorktreeInfo
+	// 显示要清理的信息
+	if !quiet {
+		i18n.Printf("发现 %d 个无效的 worktree:\n", len(prunable))
 
-	for _, wt := range worktrees {
-		if !wt.IsMain {
-			// 检查目录是否存在
-			// 这里简化处理，实际应该检查目录是否存在
-			invalidWorktrees = append(invalidWorktrees, wt)
+		for _, wt := range prunable {
+			fmt.Printf("  %s (%s)\n", color.YellowString(wt.Path), color.CyanString(wt.Branch))
 		}
 	}
 
-	if len(invalidWorktrees) == 0 {
+	if pruneDryRun {
 		if !quiet {
-			fmt.Println("没有无效的 worktree")
+			fmt.Println(i18n.T("\n这是预览模式，没有实际执行清理操作。"))
 		}
 		return nil
 	}
 
-	// 显示要清理的信息
 	if !quiet {
-		fmt.Printf("发现 %d 个无效的 worktree:\n", len(invalidWorktrees))
-
-		for _, wt := range invalidWorktrees {
-			fmt.Printf("  %s (%s)\n", color.YellowString(wt.Path), color.CyanString(wt.Branch))
-		}
-
-		if pruneDryRun {
-			fmt.Println("\n这是预览模式，没有实际执行清理操作。")
-			return nil
-		}
-
-		fmt.Print("\n确认清理这些 worktree? [y/N]: ")
-
-		var response string
-		fmt.Scanln(&response)
+		fmt.Println()
+	}
 
-		if response != "y" && response != "yes" {
-			return fmt.Errorf("取消清理")
-		}
+	ok, err := prompter.Confirm(i18n.T("确认清理这些 worktree?"), false)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return i18n.Errorf("取消清理")
 	}
 
 	// 执行清理
-	if err := repo.PruneWorktrees(); err != nil {
-		return fmt.Errorf("清理 worktree 失败: %w", err)
+	if _, err := client.Prune(cmd.Context(), gwt.PruneOptions{}); err != nil {
+		return i18n.Errorf("清理 worktree 失败: %w", err)
 	}
 
 	if !quiet {
-		fmt.Printf("✅ %s\n", color.GreenString("清理完成"))
+		fmt.Printf("✅ %s\n", color.GreenString(i18n.T("清理完成")))
 	}
 
 	return nil
@@ -2,34 +2,54 @@ package cmd
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"github.com/tinsfox/gwt/internal/git"
+	"github.com/tinsfox/gwt/internal/i18n"
+	"github.com/tinsfox/gwt/pkg/gwt"
 )
 
 var (
-	removeForce bool
+	removeForce        bool
+	removeDeleteBranch bool
+	removeDeleteRemote bool
+	removeSelector     worktreeSelector
 )
 
 // removeCmd 删除 worktree
 var removeCmd = &cobra.Command{
-	Use:     "remove <path|branch>",
+	Use:     "remove [path|branch...]",
 	Aliases: []string{"rm", "delete", "del"},
 	Short:   "删除 Git worktree",
-	Long:    `删除指定的 Git worktree，可以按路径或分支名删除。`,
+	Long: `删除指定的 Git worktree，可以按路径或分支名删除。
+
+可以一次指定多个 worktree，也可以通过 --match、--merged、--older-than 批量选择。`,
 	Example: `  # 按路径删除
   gwt remove /path/to/worktree
-  
+
   # 按分支名删除
   gwt remove feature/old-feature
-  
+
+  # 一次删除多个
+  gwt remove feature/a feature/b feature/c
+
+  # 删除所有已合并的 review 分支 worktree
+  gwt remove --match 'review/*' --merged
+
+  # 删除 30 天没有提交的 worktree
+  gwt remove --older-than 30d
+
+  # 同时删除本地分支（已合并时）
+  gwt remove feature/done --delete-branch
+
+  # 同时删除本地和远程分支（已合并到基准分支时）
+  gwt remove feature/done --delete-branch --delete-remote
+
   # 强制删除
   gwt remove feature/broken -f`,
-	Args: cobra.ExactArgs(1),
 	RunE: runRemove,
 }
 
@@ -37,121 +57,202 @@ func init() {
 	rootCmd.AddCommand(removeCmd)
 
 	removeCmd.Flags().BoolVarP(&removeForce, "force", "f", false, "强制删除")
+	removeCmd.Flags().BoolVar(&removeDeleteBranch, "delete-branch", false, "删除 worktree 后同时删除本地分支（默认: remove.delete_branch）")
+	removeCmd.Flags().BoolVar(&removeDeleteRemote, "delete-remote", false, "同时删除分支的上游远程分支（需已合并到基准分支）")
+	removeSelector.addFlags(removeCmd)
 }
 
 func runRemove(cmd *cobra.Command, args []string) error {
-	target := args[0]
+	if len(args) == 0 && !removeSelector.active() {
+		return i18n.Errorf("请指定要删除的 worktree，或使用 --match/--merged/--older-than 选择")
+	}
 
 	// 检查是否在 git 仓库中
 	repo, err := git.OpenRepository(".")
 	if err != nil {
-		return fmt.Errorf("不是 Git 仓库: %w", err)
+		return err
 	}
 
 	// 获取所有 worktree
 	worktrees, err := repo.GetWorktrees()
 	if err != nil {
-		return fmt.Errorf("获取 worktree 列表失败: %w", err)
+		return i18n.Errorf("获取 worktree 列表失败: %w", err)
 	}
 
 	if len(worktrees) == 0 {
-		return fmt.Errorf("没有 worktree 可删除")
+		return i18n.Errorf("没有 worktree 可删除")
 	}
 
 	// 查找要删除的 worktree
-	var targetWorktree *git.WorktreeInfo
-	var targetPath string
-
-	// 首先尝试按分支名匹配
-	for i, wt := range worktrees {
-		if wt.Branch == target {
-			targetWorktree = &worktrees[i]
-			targetPath = wt.Path
-			break
-		}
+	selected, err := removeSelector.selectWorktrees(repo, worktrees, args)
+	if err != nil {
+		return err
 	}
 
-	// 如果没有找到，尝试按路径匹配
-	if targetWorktree == nil {
-		// 尝试作为相对路径
-		absPath, err := filepath.Abs(target)
-		if err == nil {
-			for i, wt := range worktrees {
-				if wt.Path == absPath {
-					targetWorktree = &worktrees[i]
-					targetPath = wt.Path
-					break
-				}
+	var targets []git.WorktreeInfo
+	for _, wt := range selected {
+		// 检查是否是主工作区
+		if wt.IsMain {
+			if len(args) == 1 && !removeSelector.active() {
+				return fmt.Errorf("%w: %s", git.ErrMainWorktree, wt.Path)
 			}
+			continue
 		}
+		targets = append(targets, wt)
 	}
 
-	// 还是没有找到，尝试部分匹配
-	if targetWorktree == nil {
-		for i, wt := range worktrees {
-			if strings.Contains(wt.Path, target) || strings.Contains(wt.Branch, target) {
-				targetWorktree = &worktrees[i]
-				targetPath = wt.Path
-				break
-			}
-		}
+	deleteBranch := removeDeleteBranch
+	if !cmd.Flags().Changed("delete-branch") {
+		deleteBranch = viper.GetBool("remove.delete_branch")
 	}
 
-	if targetWorktree == nil {
-		return fmt.Errorf("未找到 worktree: %s", target)
+	if len(targets) == 0 {
+		if !quiet {
+			fmt.Println(i18n.T("没有匹配的 worktree 需要删除"))
+		}
+		return nil
 	}
 
-	// 检查是否是主工作区
-	if targetWorktree.IsMain {
-		return fmt.Errorf("不能删除主工作区")
+	client := newClient(cmd)
+
+	// 检查每个 worktree 中会丢失的工作
+	changes := make([]*git.WorktreeChanges, len(targets))
+	var unsafe []string
+	for i, wt := range targets {
+		status, err := client.Status(cmd.Context(), wt.Path)
+		if err != nil {
+			return err
+		}
+		changes[i] = status.Changes
+
+		if status.Changes != nil && status.Changes.HasUnsavedWork() {
+			unsafe = append(unsafe, wt.Path)
+		}
 	}
 
 	// 显示要删除的信息
-	if !quiet {
-		fmt.Printf("删除 worktree:\n")
-		fmt.Printf("  路径: %s\n", color.YellowString(targetPath))
-		fmt.Printf("  分支: %s\n", color.CyanString(targetWorktree.Branch))
+	if !quiet || len(unsafe) > 0 {
+		i18n.Printf("删除 worktree:\n")
+		for i, wt := range targets {
+			i18n.Printf("  路径: %s\n", color.YellowString(wt.Path))
+			i18n.Printf("  分支: %s\n", color.CyanString(wt.Branch))
 
-		if targetWorktree.IsDirty {
-			fmt.Printf("  状态: %s\n", color.RedString("有未提交的修改"))
+			if changes[i] != nil {
+				printWorktreeChanges(changes[i])
+			}
 		}
+	}
 
-		if !removeForce {
-			fmt.Print("确认删除? [y/N]: ")
+	if len(unsafe) > 0 && !removeForce {
+		return i18n.Errorf("%w（%d 个）", gwt.ErrUnsavedWork, len(unsafe))
+	}
 
-			var response string
-			fmt.Scanln(&response)
+	if !removeForce {
+		question := i18n.T("确认删除?")
+		if len(targets) > 1 {
+			question = i18n.T("确认删除这 %d 个 worktree?", len(targets))
+		}
 
-			if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
-				return fmt.Errorf("取消删除")
-			}
+		ok, err := prompter.Confirm(question, false)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return i18n.Errorf("取消删除")
 		}
 	}
 
-	// 执行删除
-	if err := repo.RemoveWorktree(targetPath); err != nil {
-		if removeForce {
-			// 强制删除，尝试手动删除目录
-			if err := os.RemoveAll(targetPath); err != nil {
-				return fmt.Errorf("强制删除目录失败: %w", err)
-			}
+	options := gwt.RemoveOptions{
+		Force:        removeForce,
+		DeleteBranch: deleteBranch,
+		DeleteRemote: removeDeleteRemote,
+		Base:         removeSelector.base,
+	}
 
-			// 清理 git worktree 记录
-			if err := repo.PruneWorktrees(); err != nil {
-				logWarning(fmt.Sprintf("清理 worktree 记录失败: %v", err))
+	var failed []string
+	for _, wt := range targets {
+		result, err := client.Remove(cmd.Context(), wt.Path, options)
+		if err != nil {
+			if len(targets) == 1 {
+				return err
 			}
-		} else {
-			return err
+			logWarning(i18n.T("删除 %s 失败: %v", wt.Path, err))
+			failed = append(failed, wt.Path)
+			continue
 		}
+
+		printRemoveResult(result)
 	}
 
-	if !quiet {
-		fmt.Printf("✅ %s\n", color.GreenString("worktree 删除成功"))
+	if len(failed) > 0 {
+		return i18n.Errorf("%d 个 worktree 删除失败: %s", len(failed), strings.Join(failed, ", "))
 	}
 
 	return nil
 }
 
+// printRemoveResult 显示单个 worktree 的删除结果
+func printRemoveResult(result *gwt.RemoveResult) {
+	if !quiet {
+		if result.Trash != nil {
+			i18n.Printf("  已备份到回收站: %s（使用 gwt restore %s 恢复）\n", color.CyanString(result.Trash.ID), result.Trash.ID)
+		}
+		fmt.Printf("✅ %s %s\n", color.GreenString(i18n.T("worktree 删除成功")), result.Worktree.Path)
+
+		if result.BranchDeleted {
+			fmt.Printf("✅ %s %s\n", color.GreenString(i18n.T("分支删除成功")), result.Worktree.Branch)
+		}
+		if result.RemoteBranch != "" {
+			fmt.Printf("✅ %s %s\n", color.GreenString(i18n.T("远程分支删除成功")), result.RemoteBranch)
+		}
+	}
+
+	for _, warning := range result.Warnings {
+		logWarning(warning.Error())
+		if hint := errorHint(warning); hint != "" && !quiet {
+			fmt.Printf("💡 %s\n", color.BlueString(hint))
+		}
+	}
+}
+
+// printWorktreeChanges 显示删除后会丢失的工作
+func printWorktreeChanges(c *git.WorktreeChanges) {
+	if len(c.Modified) > 0 {
+		fmt.Printf("  %s\n", color.RedString(i18n.T("已修改的文件 (%d):"), len(c.Modified)))
+		printFileList(c.Modified)
+	}
+	if len(c.Deleted) > 0 {
+		fmt.Printf("  %s\n", color.RedString(i18n.T("已删除的文件 (%d):"), len(c.Deleted)))
+		printFileList(c.Deleted)
+	}
+	if len(c.Untracked) > 0 {
+		fmt.Printf("  %s\n", color.RedString(i18n.T("未跟踪的文件 (%d):"), len(c.Untracked)))
+		printFileList(c.Untracked)
+	}
+	if len(c.Unpushed) > 0 {
+		fmt.Printf("  %s\n", color.RedString(i18n.T("不在其他分支或远程上的提交 (%d):"), len(c.Unpushed)))
+		for _, commit := range c.Unpushed {
+			fmt.Printf("    %s %s\n", shortHash(commit.Hash), commit.Subject)
+		}
+	}
+	if len(c.Stashes) > 0 {
+		fmt.Printf("  %s\n", color.YellowString(i18n.T("该分支的 stash (%d，删除后仍保留在仓库中):"), len(c.Stashes)))
+		printFileList(c.Stashes)
+	}
+}
+
+// printFileList 显示文件列表，过长时截断
+func printFileList(files []string) {
+	const maxShown = 10
+	for i, file := range files {
+		if i == maxShown {
+			i18n.Printf("    ... 以及另外 %d 项\n", len(files)-maxShown)
+			break
+		}
+		fmt.Printf("    %s\n", file)
+	}
+}
+
 func logWarning(msg string) {
 	if !quiet {
 		fmt.Printf("⚠️  %s\n", color.YellowString(msg))
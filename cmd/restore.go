@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/tinsfox/gwt/internal/git"
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+var (
+	restorePath string
+)
+
+// restoreCmd 恢复被删除的 worktree
+var restoreCmd = &cobra.Command{
+	Use:   "restore [id|branch]",
+	Short: "恢复被强制删除的 worktree",
+	Long: `从回收站恢复被 gwt remove -f 删除的 worktree。
+
+强制删除有未保存工作的 worktree 时，gwt 会把 HEAD 记录到 refs/gwt/trash/<id>，
+并把修改过的和未跟踪的文件打包保存到回收站。不带参数运行时列出回收站中的条目。`,
+	Example: `  # 查看回收站
+  gwt restore
+
+  # 恢复最近一次删除的 feature/login
+  gwt restore feature/login
+
+  # 恢复到其他路径
+  gwt restore 20240101-120000-feature-login --path /tmp/login`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runRestore,
+}
+
+func init() {
+	rootCmd.AddCommand(restoreCmd)
+
+	restoreCmd.Flags().StringVarP(&restorePath, "path", "p", "", "恢复到指定路径（默认: 原路径）")
+}
+
+func runRestore(cmd *cobra.Command, args []string) error {
+	// 检查是否在 git 仓库中
+	repo, err := git.OpenRepository(".")
+	if err != nil {
+		return err
+	}
+
+	entries, err := repo.TrashEntries()
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		return outputTrashEntries(entries)
+	}
+
+	// 按 ID 或分支名查找，分支名匹配时取最近一次删除的
+	var target *git.TrashEntry
+	for i, entry := range entries {
+		if entry.ID == args[0] || entry.Branch == args[0] {
+			target = &entries[i]
+			break
+		}
+	}
+
+	if target == nil {
+		return i18n.Errorf("回收站中没有找到: %s", args[0])
+	}
+
+	path := restorePath
+	if path != "" {
+		if path, err = filepath.Abs(path); err != nil {
+			return i18n.Errorf("转换路径失败: %w", err)
+		}
+	}
+
+	worktree, err := repo.RestoreFromTrash(*target, path)
+	if err != nil {
+		return i18n.Errorf("恢复 worktree 失败: %w", err)
+	}
+
+	if !quiet {
+		fmt.Printf("✅ %s\n", color.GreenString(i18n.T("worktree 恢复成功！")))
+		i18n.Printf("   路径: %s\n", worktree.Path)
+		if worktree.Branch != "" {
+			i18n.Printf("   分支: %s\n", color.CyanString(worktree.Branch))
+		}
+		i18n.Printf("   恢复的文件: %d\n", target.Files)
+	}
+
+	return nil
+}
+
+// outputTrashEntries 以表格形式显示回收站
+func outputTrashEntries(entries []git.TrashEntry) error {
+	if len(entries) == 0 {
+		fmt.Println(i18n.T("回收站是空的"))
+		return nil
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"ID", i18n.T("分支"), i18n.T("原路径"), i18n.T("文件数"), i18n.T("删除时间")})
+	table.SetBorder(true)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+
+	for _, entry := range entries {
+		branch := entry.Branch
+		if branch == "" {
+			branch = i18n.T("(分离 HEAD)")
+		}
+		table.Append([]string{
+			entry.ID,
+			branch,
+			entry.Path,
+			fmt.Sprintf("%d", entry.Files),
+			entry.RemovedAt.Format("2006-01-02 15:04"),
+		})
+	}
+
+	table.Render()
+	return nil
+}
@@ -1,11 +1,28 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	"github.com/tinsfox/gwt/internal/deps"
+	"github.com/tinsfox/gwt/internal/git"
+	"github.com/tinsfox/gwt/internal/i18n"
+	"github.com/tinsfox/gwt/internal/multiplexer"
+	"github.com/tinsfox/gwt/internal/naming"
+	"github.com/tinsfox/gwt/internal/share"
+	"github.com/tinsfox/gwt/internal/templates"
+	"github.com/tinsfox/gwt/internal/ui"
+	"github.com/tinsfox/gwt/pkg/gwt"
 )
 
 var (
@@ -16,8 +33,12 @@ var (
 
 	// 全局配置
 	cfgFile string
+	lang    string
 	verbose bool
 	quiet   bool
+
+	// prompter 负责所有交互式提问，测试中可以在执行命令前替换
+	prompter ui.Prompter
 )
 
 // rootCmd 是主命令
@@ -29,11 +50,47 @@ var rootCmd = &cobra.Command{
 它提供了直观的命令来创建、管理、切换和编辑多个 worktree，
 让你能够更高效地同时处理多个分支。`,
 	Version: getVersion(),
+	// 参数校验通过后的错误与用法无关，不再输出用法
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		cmd.SilenceUsage = true
+
+		if prompter == nil {
+			prompter = ui.NewPrompter(cmd.InOrStdin(), cmd.OutOrStdout(), ui.PromptOptions{
+				Yes:     viper.GetBool("yes"),
+				NoInput: viper.GetBool("no_input"),
+			})
+		}
+	},
+	SilenceErrors: true,
 }
 
-// Execute 执行根命令
+// Execute 执行根命令，收到中断信号时取消正在执行的 git 命令
+//
+// 错误及其提示已输出到标准错误，调用方用 ExitCode 确定退出码。
 func Execute() error {
-	return rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// 帮助信息在 cobra.OnInitialize 之前输出，需要提前读取配置并确定语言
+	err := setupLanguage(os.Args[1:])
+	if err == nil {
+		err = rootCmd.ExecuteContext(ctx)
+	}
+	if err != nil {
+		printError(rootCmd.ErrOrStderr(), err)
+	}
+	return err
+}
+
+// newClient 创建命令使用的 gwt 客户端
+func newClient(cmd *cobra.Command) *gwt.Client {
+	return gwt.New(gwt.Options{
+		Dir:    ".",
+		Stdout: cmd.OutOrStdout(),
+		Stderr: cmd.ErrOrStderr(),
+		Stdin:  cmd.InOrStdin(),
+		Git:    git.DefaultRunner,
+	})
 }
 
 // SetVersionInfo 设置版本信息
@@ -59,10 +116,15 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "配置文件路径 (默认: $HOME/.gwt.yaml)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "详细输出")
 	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "安静模式，只显示错误信息")
+	rootCmd.PersistentFlags().BoolP("yes", "y", false, "对所有确认问题回答“是”")
+	rootCmd.PersistentFlags().Bool("no-input", false, "不读取输入，需要输入时报错（也可以设置 GWT_NO_INPUT=1）")
+	rootCmd.PersistentFlags().StringVar(&lang, "lang", "", "界面语言: en, zh-CN（默认: ui.language 配置或 LANG）")
 
 	// 绑定到 viper
 	viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
 	viper.BindPFlag("quiet", rootCmd.PersistentFlags().Lookup("quiet"))
+	viper.BindPFlag("yes", rootCmd.PersistentFlags().Lookup("yes"))
+	viper.BindPFlag("no_input", rootCmd.PersistentFlags().Lookup("no-input"))
 }
 
 // initConfig 读取配置文件
@@ -90,10 +152,11 @@ func initConfig() {
 
 	// 读取环境变量
 	viper.SetEnvPrefix("GWT")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	viper.AutomaticEnv()
 
 	// 设置默认值
-	setDefaults()
+	setDefaults(viper.GetViper())
 
 	// 读取配置文件
 	if err := viper.ReadInConfig(); err == nil {
@@ -101,22 +164,223 @@ func initConfig() {
 			fmt.Println("Using config file:", viper.ConfigFileUsed())
 		}
 	}
+
+	configureGit()
+}
+
+// projectConfigName 是项目配置文件名，放在仓库根目录中随代码提交，供团队共享
+const projectConfigName = ".gwt.yaml"
+
+// loadProjectConfig 读取当前 worktree 根目录中的项目配置，不存在时返回 nil
+//
+// 用户配置恰好就是这个文件时（在仓库根目录运行且没有全局配置）返回全局的 viper，
+// 调用方据此避免把同一份配置读取两次。
+func loadProjectConfig(repo *git.Repository) (*viper.Viper, error) {
+	root := repo.Worktree
+	if root == "" {
+		root = repo.MainWorktree
+	}
+	if root == "" {
+		return nil, nil
+	}
+
+	file := filepath.Join(root, projectConfigName)
+	if _, err := os.Stat(file); err != nil {
+		return nil, nil
+	}
+	if used, err := filepath.Abs(viper.ConfigFileUsed()); err == nil && used == file {
+		return viper.GetViper(), nil
+	}
+
+	config := viper.New()
+	config.SetConfigFile(file)
+	if err := config.ReadInConfig(); err != nil {
+		return nil, i18n.Errorf("读取项目配置 %s 失败: %w", file, err)
+	}
+	return config, nil
+}
+
+// unmarshalConfig 依次用项目配置和用户配置解析 key，用户配置中设置的字段覆盖项目配置
+func unmarshalConfig(repo *git.Repository, key string, out interface{}) error {
+	project, err := loadProjectConfig(repo)
+	if err != nil {
+		return err
+	}
+	if project != nil && project != viper.GetViper() {
+		if err := project.UnmarshalKey(key, out); err != nil {
+			return i18n.Errorf("解析项目配置 %s 失败: %w", key, err)
+		}
+	}
+	if err := viper.UnmarshalKey(key, out); err != nil {
+		return i18n.Errorf("解析配置 %s 失败: %w", key, err)
+	}
+	return nil
+}
+
+// configureGit 根据配置设置执行 git 命令的方式，-v 时记录每条 git 命令
+func configureGit() {
+	var runner git.Runner = git.ExecRunner{Path: viper.GetString("git.path")}
+	if verbose {
+		runner = git.TraceRunner{Runner: runner, Out: os.Stderr}
+	}
+	git.DefaultRunner = runner
+}
+
+// setupLanguage 根据 --lang、ui.language 和 LANG 设置界面语言，并翻译命令的帮助信息
+func setupLanguage(args []string) error {
+	flags := pflag.NewFlagSet("gwt", pflag.ContinueOnError)
+	flags.ParseErrorsWhitelist.UnknownFlags = true
+	flags.SetOutput(io.Discard)
+	flags.StringVar(&cfgFile, "config", "", "")
+	flags.StringVar(&lang, "lang", "", "")
+	flags.BoolP("help", "h", false, "")
+	flags.Parse(args)
+
+	initConfig()
+
+	if lang != "" && i18n.Normalize(lang) == "" {
+		return i18n.Errorf("不支持的语言: %s（支持: %s）", lang, strings.Join(i18n.Languages(), ", "))
+	}
+	if err := i18n.SetLanguage(i18n.Detect(lang, viper.GetString("ui.language"))); err != nil {
+		return err
+	}
+
+	localizeCommand(rootCmd)
+	return nil
+}
+
+// localizeCommand 翻译命令及其子命令的说明、示例和参数说明
+func localizeCommand(cmd *cobra.Command) {
+	cmd.Short = i18n.T(cmd.Short)
+	cmd.Long = i18n.T(cmd.Long)
+	cmd.Example = i18n.T(cmd.Example)
+
+	localizeFlag := func(flag *pflag.Flag) {
+		flag.Usage = i18n.T(flag.Usage)
+	}
+	cmd.LocalFlags().VisitAll(localizeFlag)
+	cmd.PersistentFlags().VisitAll(localizeFlag)
+
+	for _, sub := range cmd.Commands() {
+		localizeCommand(sub)
+	}
 }
 
 // setDefaults 设置配置默认值
-func setDefaults() {
+func setDefaults(v *viper.Viper) {
 	// 编辑器配置
-	viper.SetDefault("editor.default", detectDefaultEditor())
-	viper.SetDefault("editor.fallback", []string{"vim", "nano", "code"})
+	v.SetDefault("editor.default", detectDefaultEditor())
+	v.SetDefault("editor.fallback", []string{"vim", "nano", "code"})
 
 	// 路径配置
-	viper.SetDefault("paths.default", "")
-	viper.SetDefault("paths.base", "")
+	v.SetDefault("paths.default", "")
+	v.SetDefault("paths.base", "")
 
 	// 显示配置
-	viper.SetDefault("display.color", true)
-	viper.SetDefault("display.icons", true)
-	viper.SetDefault("display.table_style", "default")
+	v.SetDefault("display.color", true)
+	v.SetDefault("display.icons", true)
+	v.SetDefault("display.table_style", "default")
+
+	// 终端复用器配置
+	v.SetDefault("multiplexer.default", "tmux")
+	v.SetDefault("multiplexer.mode", "session")
+
+	// 界面配置，为空时根据 LANG 确定
+	v.SetDefault("ui.language", "")
+
+	// git 配置
+	v.SetDefault("git.path", "git")
+
+	// 删除配置
+	v.SetDefault("remove.delete_branch", false)
+
+	// 同步配置
+	v.SetDefault("sync.strategy", "ff")
+	v.SetDefault("sync.autostash", false)
+	v.SetDefault("sync.base", "")
+
+	// 共享文件配置
+	v.SetDefault("shared_files", []string{})
+	v.SetDefault("shared_files_mode", string(share.ModeSymlink))
+}
+
+// boundConfigKeys 是与命令行参数绑定、也可以写在配置文件中的配置项
+var boundConfigKeys = []string{"verbose", "quiet", "yes", "no_input"}
+
+// configStructs 是按结构体解析的配置项，其下的键必须是结构体的字段
+var configStructs = map[string]interface{}{
+	"branch_policy":      naming.Policy{},
+	"deps":               deps.Config{},
+	"multiplexer.layout": multiplexer.Layout{},
+}
+
+// configMaps 是以名称为键、值按结构体解析的配置项，如 templates.<名称>.<字段>
+var configMaps = map[string]interface{}{
+	"templates":       templates.Template{},
+	"sparse_profiles": sparseProfile{},
+}
+
+// configLists 是值为结构体列表的配置项，如 sync.policies，列表元素的键必须是结构体的字段
+var configLists = map[string]interface{}{
+	"sync.policies": syncPolicy{},
+}
+
+// knownConfigKey 判断配置键是否为 gwt 使用的配置项
+func knownConfigKey(key string) bool {
+	if _, ok := configLists[key]; ok {
+		return true
+	}
+
+	defaults := viper.New()
+	setDefaults(defaults)
+
+	for _, known := range append(defaults.AllKeys(), boundConfigKeys...) {
+		if key == known {
+			return true
+		}
+	}
+
+	for section, schema := range configStructs {
+		if field, ok := strings.CutPrefix(key, section+"."); ok {
+			return hasConfigField(schema, strings.Split(field, ".")[0])
+		}
+	}
+	for section, schema := range configMaps {
+		if rest, ok := strings.CutPrefix(key, section+"."); ok {
+			parts := strings.SplitN(rest, ".", 3)
+			return len(parts) >= 2 && hasConfigField(schema, parts[1])
+		}
+	}
+	return false
+}
+
+// unknownListFields 返回 config 中结构体列表配置项里的未知字段，如 sync.policies[0].stratgy
+func unknownListFields(config *viper.Viper) []string {
+	var unknown []string
+	for section, schema := range configLists {
+		items, _ := config.Get(section).([]interface{})
+		for i, item := range items {
+			fields, _ := item.(map[string]interface{})
+			for name := range fields {
+				if !hasConfigField(schema, strings.ToLower(name)) {
+					unknown = append(unknown, fmt.Sprintf("%s[%d].%s", section, i, name))
+				}
+			}
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// hasConfigField 检查结构体是否有 mapstructure 标签为 name 的字段
+func hasConfigField(schema interface{}, name string) bool {
+	t := reflect.TypeOf(schema)
+	for i := 0; i < t.NumField(); i++ {
+		if tag := strings.Split(t.Field(i).Tag.Get("mapstructure"), ",")[0]; tag == name {
+			return true
+		}
+	}
+	return false
 }
 
 // detectDefaultEditor 检测默认编辑器
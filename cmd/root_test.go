@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/tinsfox/gwt/internal/git"
+)
+
+func TestConfigureGitVerbose(t *testing.T) {
+	defer func(runner git.Runner, v bool) {
+		git.DefaultRunner, verbose = runner, v
+	}(git.DefaultRunner, verbose)
+	viper.Set("git.path", "/opt/git/bin/git")
+	defer viper.Set("git.path", "")
+
+	verbose = false
+	configureGit()
+	if runner, ok := git.DefaultRunner.(git.ExecRunner); !ok || runner.Path != "/opt/git/bin/git" {
+		t.Errorf("without -v DefaultRunner = %#v, want ExecRunner using git.path", git.DefaultRunner)
+	}
+
+	verbose = true
+	configureGit()
+	trace, ok := git.DefaultRunner.(git.TraceRunner)
+	if !ok {
+		t.Fatalf("with -v DefaultRunner = %#v, want TraceRunner", git.DefaultRunner)
+	}
+	if trace.Out != os.Stderr {
+		t.Errorf("TraceRunner writes to %v, want os.Stderr", trace.Out)
+	}
+	if runner, ok := trace.Runner.(git.ExecRunner); !ok || runner.Path != "/opt/git/bin/git" {
+		t.Errorf("TraceRunner wraps %#v, want ExecRunner using git.path", trace.Runner)
+	}
+}
+
+func TestKnownConfigKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"sync.strategy", true},
+		{"sync.autostash", true},
+		{"sync.base", true},
+		{"sync.policies", true},
+		{"sync.bsae", false},
+		{"deps.method", true},
+		{"deps.methd", false},
+		{"templates.web.description", true},
+		{"templates.web.descripton", false},
+		{"verbose", true},
+		{"unknown", false},
+	}
+
+	for _, tt := range tests {
+		if got := knownConfigKey(tt.key); got != tt.want {
+			t.Errorf("knownConfigKey(%q) = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestUnknownListFields(t *testing.T) {
+	config := viper.New()
+	config.SetConfigType("yaml")
+	err := config.ReadConfig(strings.NewReader(`
+sync:
+  base: main
+  policies:
+    - match: "release/*"
+      strategy: merge
+    - match: "feature/*"
+      stratgy: rebase
+      Onto: develop
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range config.AllKeys() {
+		if !knownConfigKey(key) {
+			t.Errorf("knownConfigKey(%q) = false", key)
+		}
+	}
+	if got, want := unknownListFields(config), []string{"sync.policies[1].stratgy"}; !slices.Equal(got, want) {
+		t.Errorf("unknownListFields() = %v, want %v", got, want)
+	}
+}
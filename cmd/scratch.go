@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tinsfox/gwt/internal/git"
+	"github.com/tinsfox/gwt/internal/i18n"
+	"github.com/tinsfox/gwt/pkg/gwt"
+)
+
+var (
+	scratchPath   string
+	scratchNoDeps bool
+)
+
+// scratchCmd 创建临时 worktree
+var scratchCmd = &cobra.Command{
+	Use:   "scratch [ref]",
+	Short: "以分离 HEAD 创建自动命名的临时 worktree",
+	Long: `以分离 HEAD 检出指定的提交、标签或分支（默认: 当前 HEAD），创建一个自动命名的临时 worktree，
+适合快速试验、复现问题或查看旧版本。
+
+临时 worktree 不创建分支，用完后运行 gwt clean 统一删除。`,
+	Example: `  # 基于当前 HEAD 创建临时 worktree
+  gwt scratch
+
+  # 查看 v1.2.0 版本
+  gwt scratch v1.2.0`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runScratch,
+}
+
+func init() {
+	rootCmd.AddCommand(scratchCmd)
+
+	scratchCmd.Flags().StringVarP(&scratchPath, "path", "p", "", "worktree 路径（默认: 自动生成）")
+	scratchCmd.Flags().BoolVar(&scratchNoDeps, "no-deps", false, "不复用依赖目录")
+}
+
+func runScratch(cmd *cobra.Command, args []string) error {
+	ref := "HEAD"
+	if len(args) > 0 {
+		ref = args[0]
+	}
+
+	repo, err := git.OpenRepository(".")
+	if err != nil {
+		return err
+	}
+
+	client := newClient(cmd)
+	now := time.Now()
+
+	// 自动生成的路径已存在时（例如同一秒内多次运行）依次尝试带序号的路径
+	var result *gwt.CreateResult
+	for attempt := 1; ; attempt++ {
+		path := scratchPath
+		if path == "" {
+			path = scratchWorktreePath(repo, now, attempt)
+		}
+
+		result, err = client.Create(cmd.Context(), gwt.CreateOptions{
+			Path:    path,
+			Base:    ref,
+			Detach:  true,
+			Scratch: true,
+		})
+		if scratchPath == "" && errors.Is(err, gwt.ErrPathExists) && attempt < maxScratchAttempts {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		break
+	}
+	worktree := result.Worktree
+
+	if !quiet {
+		fmt.Printf("✅ %s\n", color.GreenString(i18n.T("临时 worktree 创建成功！")))
+		i18n.Printf("   路径: %s\n", color.YellowString(worktree.Path))
+		i18n.Printf("   提交: %s\n", color.CyanString(worktree.Describe))
+		fmt.Println()
+		fmt.Printf("💡 %s\n", color.BlueString(i18n.T("提示:")))
+		i18n.Printf("   cd %s    # 进入 worktree 目录\n", worktree.Path)
+		i18n.Printf("   gwt clean    # 删除所有临时 worktree\n")
+	}
+
+	shareCreatedWorktree(repo, worktree.Path)
+	if !scratchNoDeps {
+		reuseDependencies(repo, worktree.Path, false)
+	}
+
+	return nil
+}
+
+// maxScratchAttempts 是自动生成临时 worktree 路径的最多尝试次数
+const maxScratchAttempts = 100
+
+// scratchWorktreePath 返回临时 worktree 的默认路径，attempt 大于 1 时加上序号
+//
+// 裸仓库布局中与其他 worktree 并列存放，否则放在主工作区旁边，以仓库名为前缀。
+func scratchWorktreePath(repo *git.Repository, now time.Time, attempt int) string {
+	name := "scratch-" + now.Format("20060102-150405")
+	if attempt > 1 {
+		name += fmt.Sprintf("-%d", attempt)
+	}
+	if root := repo.LayoutRoot(); root != "" {
+		return filepath.Join(root, name)
+	}
+	return filepath.Join(filepath.Dir(repo.MainWorktree), repositoryName(repo)+"-"+name)
+}
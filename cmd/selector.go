@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tinsfox/gwt/internal/git"
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+// worktreeSelector 批量选择 worktree 的条件
+type worktreeSelector struct {
+	match     string
+	merged    bool
+	base      string
+	olderThan string
+}
+
+// addFlags 为命令添加选择条件 flags
+func (s *worktreeSelector) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&s.match, "match", "", "按分支名或目录名通配符选择，如 'review/*'")
+	cmd.Flags().BoolVar(&s.merged, "merged", false, "只选择已合并到基准分支的 worktree")
+	cmd.Flags().StringVar(&s.base, "base", "", "--merged 使用的基准分支（默认: 仓库默认分支）")
+	cmd.Flags().StringVar(&s.olderThan, "older-than", "", "只选择最后提交早于指定时间的 worktree，如 30d、2w、12h")
+}
+
+// active 是否设置了任何选择条件
+func (s *worktreeSelector) active() bool {
+	return s.match != "" || s.merged || s.olderThan != ""
+}
+
+// selectWorktrees 根据位置参数和选择条件确定目标 worktree
+//
+// 位置参数按 findWorktree 的规则逐个匹配；设置了选择条件时，
+// 会在参数匹配结果（没有参数时为全部 worktree）上进一步过滤。
+func (s *worktreeSelector) selectWorktrees(repo *git.Repository, worktrees []git.WorktreeInfo, targets []string) ([]git.WorktreeInfo, error) {
+	candidates := worktrees
+	if len(targets) > 0 {
+		candidates = nil
+		seen := make(map[string]bool)
+		for _, target := range targets {
+			wt, err := findWorktree(worktrees, target)
+			if err != nil {
+				return nil, err
+			}
+			if !seen[wt.Path] {
+				seen[wt.Path] = true
+				candidates = append(candidates, *wt)
+			}
+		}
+	}
+
+	if !s.active() {
+		return candidates, nil
+	}
+
+	var cutoff time.Time
+	if s.olderThan != "" {
+		age, err := parseAge(s.olderThan)
+		if err != nil {
+			return nil, err
+		}
+		cutoff = time.Now().Add(-age)
+	}
+
+	var merged map[string]bool
+	if s.merged {
+		base := s.base
+		if base == "" {
+			defaultBranch, err := repo.DefaultBranch()
+			if err != nil {
+				return nil, i18n.Errorf("请使用 --base 指定基准分支: %w", err)
+			}
+			base = defaultBranch
+		}
+
+		branches, err := repo.MergedBranches(base)
+		if err != nil {
+			return nil, err
+		}
+
+		merged = make(map[string]bool)
+		for _, branch := range branches {
+			// 基准分支本身总是"已合并"，不应被选中
+			if branch != base {
+				merged[branch] = true
+			}
+		}
+	}
+
+	var selected []git.WorktreeInfo
+	for _, wt := range candidates {
+		if s.match != "" && !matchWorktree(wt, s.match) {
+			continue
+		}
+		if merged != nil && (wt.Branch == "" || !merged[wt.Branch]) {
+			continue
+		}
+		if !cutoff.IsZero() && (wt.LastCommit.Date.IsZero() || wt.LastCommit.Date.After(cutoff)) {
+			continue
+		}
+		selected = append(selected, wt)
+	}
+
+	return selected, nil
+}
+
+// matchWorktree 检查 worktree 的分支名或目录名是否匹配通配符
+func matchWorktree(wt git.WorktreeInfo, pattern string) bool {
+	if ok, _ := path.Match(pattern, wt.Branch); ok && wt.Branch != "" {
+		return true
+	}
+	ok, _ := filepath.Match(pattern, filepath.Base(wt.Path))
+	return ok
+}
+
+// parseAge 解析时长，支持 Go duration 格式以及 d（天）、w（周）单位
+func parseAge(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if len(value) > 1 {
+		unit := value[len(value)-1]
+		if unit == 'd' || unit == 'w' {
+			n, err := strconv.Atoi(value[:len(value)-1])
+			if err == nil && n >= 0 {
+				days := n
+				if unit == 'w' {
+					days = n * 7
+				}
+				return time.Duration(days) * 24 * time.Hour, nil
+			}
+		}
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, i18n.Errorf("无效的时长: %s（示例: 30d、2w、12h）", value)
+	}
+	return d, nil
+}
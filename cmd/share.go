@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tinsfox/gwt/internal/git"
+	"github.com/tinsfox/gwt/internal/i18n"
+	"github.com/tinsfox/gwt/internal/share"
+)
+
+var shareForce bool
+
+// shareCmd 管理从主工作区共享到其他 worktree 的文件
+var shareCmd = &cobra.Command{
+	Use:   "share",
+	Short: "管理从主工作区共享的文件",
+	Long: `把主工作区中不受版本控制的文件（如 .env.local、.idea/、本地证书）共享到其他 worktree。
+
+在配置中用 shared_files 列出要共享的文件（支持通配符，目录包括其下所有文件），
+shared_files_mode 指定共享方式：symlink（默认，创建指向主工作区的符号链接）
+或 copy（复制）。gwt create 创建 worktree 时会自动共享这些文件。
+
+  shared_files:
+    - .env.local
+    - .idea/
+    - .vscode/settings.json
+    - certs/*.pem
+  shared_files_mode: symlink
+
+受版本控制的文件不会被共享。`,
+}
+
+// shareSyncCmd 把共享文件重新应用到已有的 worktree
+var shareSyncCmd = &cobra.Command{
+	Use:   "sync [path|branch...]",
+	Short: "把共享文件应用到已有的 worktree",
+	Long: `把 shared_files 中的文件应用到指定的 worktree，不指定时应用到除主工作区外的所有 worktree。
+
+已经一致的文件不会改动；被修改过的文件默认跳过，使用 --force 覆盖。`,
+	Example: `  # 应用到所有 worktree
+  gwt share sync
+
+  # 覆盖 feature/login 中被修改过的共享文件
+  gwt share sync feature/login --force`,
+	RunE: runShareSync,
+}
+
+// shareStatusCmd 显示共享文件与主工作区的差异
+var shareStatusCmd = &cobra.Command{
+	Use:   "status [path|branch...]",
+	Short: "显示共享文件与主工作区的差异",
+	RunE:  runShareStatus,
+}
+
+func init() {
+	rootCmd.AddCommand(shareCmd)
+	shareCmd.AddCommand(shareSyncCmd)
+	shareCmd.AddCommand(shareStatusCmd)
+
+	shareSyncCmd.Flags().BoolVarP(&shareForce, "force", "f", false, "覆盖被修改过的共享文件")
+}
+
+// sharedFiles 是从主工作区共享的文件及共享方式
+type sharedFiles struct {
+	source string
+	mode   share.Mode
+	files  []string
+}
+
+// loadSharedFiles 读取 shared_files 配置并展开为主工作区中的文件
+//
+// 项目配置和用户配置中的 shared_files 合并使用，shared_files_mode 以用户配置优先。
+// 没有配置共享文件时返回 nil。
+func loadSharedFiles(repo *git.Repository) (*sharedFiles, error) {
+	project, err := loadProjectConfig(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var patterns []string
+	mode := viper.GetString("shared_files_mode")
+	if project != nil && project != viper.GetViper() {
+		patterns = append(patterns, project.GetStringSlice("shared_files")...)
+		if project.IsSet("shared_files_mode") && !viper.InConfig("shared_files_mode") {
+			mode = project.GetString("shared_files_mode")
+		}
+	}
+	patterns = append(patterns, viper.GetStringSlice("shared_files")...)
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+
+	shareMode, err := share.ParseMode(mode)
+	if err != nil {
+		return nil, err
+	}
+	if repo.MainWorktree == "" {
+		return nil, i18n.Errorf("裸仓库没有主工作区，无法共享文件")
+	}
+
+	files, err := share.Collect(repo.MainWorktree, patterns)
+	if err != nil {
+		return nil, err
+	}
+	tracked, err := repo.TrackedFiles(repo.MainWorktree, files)
+	if err != nil {
+		return nil, err
+	}
+
+	shared := &sharedFiles{source: repo.MainWorktree, mode: shareMode}
+	for _, file := range files {
+		if !tracked[file] {
+			shared.files = append(shared.files, file)
+		}
+	}
+	return shared, nil
+}
+
+// apply 把共享文件应用到 target，返回新共享的文件数和被跳过的已修改文件
+func (s *sharedFiles) apply(target string, force bool) (int, []string, error) {
+	applied := 0
+	var skipped []string
+	for _, file := range s.files {
+		state, err := share.Apply(s.source, target, file, s.mode, force)
+		if err != nil {
+			return applied, skipped, i18n.Errorf("共享 %s 失败: %w", file, err)
+		}
+		switch {
+		case state == share.StateMissing, state == share.StateModified && force:
+			applied++
+		case state == share.StateModified:
+			skipped = append(skipped, file)
+		}
+	}
+	return applied, skipped, nil
+}
+
+// shareTargets 返回要应用共享文件的 worktree，不指定时为除主工作区外的所有 worktree
+func shareTargets(repo *git.Repository, args []string) ([]git.WorktreeInfo, error) {
+	worktrees, err := repo.ListWorktrees()
+	if err != nil {
+		return nil, i18n.Errorf("获取 worktree 列表失败: %w", err)
+	}
+
+	if len(args) == 0 {
+		var targets []git.WorktreeInfo
+		for _, wt := range worktrees {
+			if !wt.IsMain && wt.Prunable == "" && wt.Path != repo.MainWorktree {
+				targets = append(targets, wt)
+			}
+		}
+		return targets, nil
+	}
+
+	var targets []git.WorktreeInfo
+	for _, arg := range args {
+		wt, err := findWorktree(worktrees, arg)
+		if err != nil {
+			return nil, err
+		}
+		if wt.Path == repo.MainWorktree {
+			return nil, i18n.Errorf("%s 是共享文件的来源，不需要同步", wt.Path)
+		}
+		targets = append(targets, *wt)
+	}
+	return targets, nil
+}
+
+func runShareSync(cmd *cobra.Command, args []string) error {
+	repo, err := git.OpenRepository(".")
+	if err != nil {
+		return err
+	}
+
+	shared, err := loadSharedFiles(repo)
+	if err != nil {
+		return err
+	}
+	if shared == nil {
+		i18n.Printf("没有配置共享文件（shared_files）\n")
+		return nil
+	}
+
+	targets, err := shareTargets(repo, args)
+	if err != nil {
+		return err
+	}
+
+	for _, wt := range targets {
+		applied, skipped, err := shared.apply(wt.Path, shareForce)
+		if err != nil {
+			return err
+		}
+		if quiet {
+			continue
+		}
+
+		fmt.Printf("%s %s\n", color.CyanString(describeWorktree(wt)), color.HiBlackString(wt.Path))
+		i18n.Printf("  ✓ 共享了 %d 个文件\n", applied)
+		for _, file := range skipped {
+			fmt.Printf("  ⚠️  %s\n", color.YellowString(i18n.T("%s 已被修改，跳过（使用 --force 覆盖）", file)))
+		}
+	}
+
+	return nil
+}
+
+func runShareStatus(cmd *cobra.Command, args []string) error {
+	repo, err := git.OpenRepository(".")
+	if err != nil {
+		return err
+	}
+
+	shared, err := loadSharedFiles(repo)
+	if err != nil {
+		return err
+	}
+	if shared == nil {
+		i18n.Printf("没有配置共享文件（shared_files）\n")
+		return nil
+	}
+
+	targets, err := shareTargets(repo, args)
+	if err != nil {
+		return err
+	}
+
+	i18n.Printf("共享方式: %s，来源: %s\n", shared.mode, shared.source)
+
+	drifted := 0
+	for _, wt := range targets {
+		fmt.Println()
+		fmt.Printf("%s %s\n", color.CyanString(describeWorktree(wt)), color.HiBlackString(wt.Path))
+
+		clean := true
+		for _, file := range shared.files {
+			state, err := share.Inspect(shared.source, wt.Path, file, shared.mode)
+			if err != nil {
+				return i18n.Errorf("检查 %s 失败: %w", file, err)
+			}
+			switch state {
+			case share.StateMissing:
+				fmt.Printf("  %s %s\n", color.RedString("✗"), i18n.T("%s（缺失）", file))
+				clean = false
+			case share.StateModified:
+				fmt.Printf("  %s %s\n", color.YellowString("~"), i18n.T("%s（已修改）", file))
+				clean = false
+			}
+		}
+
+		if clean {
+			i18n.Printf("  ✓ %d 个共享文件与主工作区一致\n", len(shared.files))
+		} else {
+			drifted++
+		}
+	}
+
+	if drifted > 0 {
+		fmt.Println()
+		fmt.Printf("💡 %s\n", color.BlueString(i18n.T("运行 `gwt share sync` 补充缺失的文件，加 --force 覆盖已修改的文件")))
+	}
+
+	return nil
+}
@@ -0,0 +1,297 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/tinsfox/gwt/internal/git"
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+var (
+	sparseWorktree string
+	sparseProfiles []string
+)
+
+// sparseProfile 是配置中 sparse_profiles 下的一组稀疏检出目录
+type sparseProfile struct {
+	Description string   `mapstructure:"description"`
+	Dirs        []string `mapstructure:"dirs"`
+}
+
+// sparseCmd 管理 worktree 的稀疏检出
+var sparseCmd = &cobra.Command{
+	Use:   "sparse",
+	Short: "管理 worktree 的稀疏检出",
+	Long: `稀疏检出（cone 模式）只检出指定的目录以及根目录中的文件，适合只需要大仓库中少数目录的任务。
+
+使用 gwt create --sparse <目录> 创建稀疏检出的 worktree，或者用下面的子命令修改已有的 worktree。
+常用的目录组合可以在配置的 sparse_profiles 下定义，用 --profile 引用：
+
+  sparse_profiles:
+    web:
+      description: 前端
+      dirs: [apps/web, libs/ui]`,
+}
+
+// sparseSetCmd 设置稀疏检出的目录
+var sparseSetCmd = &cobra.Command{
+	Use:   "set [dir...]",
+	Short: "设置稀疏检出的目录，没有启用时启用",
+	Example: `  # 当前 worktree 只检出 apps/web 和 libs/ui
+  gwt sparse set apps/web libs/ui
+
+  # 使用 web 配置组
+  gwt sparse set --profile web -w feature/login`,
+	RunE: runSparseSet,
+}
+
+// sparseAddCmd 添加稀疏检出的目录
+var sparseAddCmd = &cobra.Command{
+	Use:   "add [dir...]",
+	Short: "在稀疏检出中添加目录",
+	RunE:  runSparseAdd,
+}
+
+// sparseListCmd 列出稀疏检出的目录
+var sparseListCmd = &cobra.Command{
+	Use:     "list [path|branch]",
+	Aliases: []string{"ls"},
+	Short:   "列出 worktree 稀疏检出的目录",
+	Args:    cobra.MaximumNArgs(1),
+	RunE:    runSparseList,
+}
+
+// sparseDisableCmd 关闭稀疏检出
+var sparseDisableCmd = &cobra.Command{
+	Use:   "disable [path|branch]",
+	Short: "关闭稀疏检出，检出所有文件",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runSparseDisable,
+}
+
+// sparseProfilesCmd 列出配置的稀疏检出组
+var sparseProfilesCmd = &cobra.Command{
+	Use:   "profiles",
+	Short: "列出配置中的稀疏检出组",
+	Args:  cobra.NoArgs,
+	RunE:  runSparseProfiles,
+}
+
+func init() {
+	rootCmd.AddCommand(sparseCmd)
+	sparseCmd.AddCommand(sparseSetCmd)
+	sparseCmd.AddCommand(sparseAddCmd)
+	sparseCmd.AddCommand(sparseListCmd)
+	sparseCmd.AddCommand(sparseDisableCmd)
+	sparseCmd.AddCommand(sparseProfilesCmd)
+
+	for _, c := range []*cobra.Command{sparseSetCmd, sparseAddCmd} {
+		c.Flags().StringVarP(&sparseWorktree, "worktree", "w", "", "要修改的 worktree（默认: 当前 worktree）")
+		c.Flags().StringSliceVarP(&sparseProfiles, "profile", "p", nil, "使用 sparse_profiles 中的目录组")
+	}
+}
+
+// loadSparseProfiles 读取配置中的稀疏检出组，用户配置覆盖项目配置中的同名组
+func loadSparseProfiles(repo *git.Repository) (map[string]sparseProfile, error) {
+	profiles := make(map[string]sparseProfile)
+	if err := unmarshalConfig(repo, "sparse_profiles", &profiles); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// resolveSparseDirs 合并命令行中的目录和稀疏检出组中的目录，去除重复
+func resolveSparseDirs(repo *git.Repository, dirs, profileNames []string) ([]string, error) {
+	all := append([]string{}, dirs...)
+	if len(profileNames) > 0 {
+		profiles, err := loadSparseProfiles(repo)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range profileNames {
+			profile, ok := profiles[name]
+			if !ok {
+				return nil, i18n.Errorf("未找到稀疏检出组: %s", name)
+			}
+			all = append(all, profile.Dirs...)
+		}
+	}
+
+	seen := make(map[string]bool)
+	var result []string
+	for _, dir := range all {
+		dir = strings.Trim(strings.TrimSpace(dir), "/")
+		if dir == "" || seen[dir] {
+			continue
+		}
+		seen[dir] = true
+		result = append(result, dir)
+	}
+	return result, nil
+}
+
+// sparseTarget 打开仓库并查找要操作的 worktree
+func sparseTarget(cmd *cobra.Command, target string) (*git.Repository, string, error) {
+	repo, err := git.OpenRepository(".")
+	if err != nil {
+		return nil, "", err
+	}
+
+	wt, err := newClient(cmd).Find(cmd.Context(), target)
+	if err != nil {
+		return nil, "", err
+	}
+	return repo.WithContext(cmd.Context()), wt.Path, nil
+}
+
+func runSparseSet(cmd *cobra.Command, args []string) error {
+	repo, path, err := sparseTarget(cmd, sparseWorktree)
+	if err != nil {
+		return err
+	}
+
+	dirs, err := resolveSparseDirs(repo, args, sparseProfiles)
+	if err != nil {
+		return err
+	}
+
+	if err := repo.SetSparse(path, dirs); err != nil {
+		return err
+	}
+
+	return printSparseDirs(repo, path)
+}
+
+func runSparseAdd(cmd *cobra.Command, args []string) error {
+	repo, path, err := sparseTarget(cmd, sparseWorktree)
+	if err != nil {
+		return err
+	}
+
+	dirs, err := resolveSparseDirs(repo, args, sparseProfiles)
+	if err != nil {
+		return err
+	}
+	if len(dirs) == 0 {
+		return i18n.Errorf("请指定要添加的目录或稀疏检出组")
+	}
+
+	if err := repo.AddSparse(path, dirs); err != nil {
+		if errors.Is(err, git.ErrNotSparse) {
+			return i18n.Errorf("%w: %s，请使用 gwt sparse set 启用", err, path)
+		}
+		return err
+	}
+
+	return printSparseDirs(repo, path)
+}
+
+func runSparseList(cmd *cobra.Command, args []string) error {
+	target := ""
+	if len(args) > 0 {
+		target = args[0]
+	}
+
+	repo, path, err := sparseTarget(cmd, target)
+	if err != nil {
+		return err
+	}
+
+	return printSparseDirs(repo, path)
+}
+
+func runSparseDisable(cmd *cobra.Command, args []string) error {
+	target := ""
+	if len(args) > 0 {
+		target = args[0]
+	}
+
+	repo, path, err := sparseTarget(cmd, target)
+	if err != nil {
+		return err
+	}
+
+	if err := repo.DisableSparse(path); err != nil {
+		return err
+	}
+
+	if !quiet {
+		fmt.Printf("✓ %s %s\n", color.GreenString(i18n.T("已关闭稀疏检出")), path)
+	}
+	return nil
+}
+
+func runSparseProfiles(cmd *cobra.Command, args []string) error {
+	repo, err := git.OpenRepository(".")
+	if err != nil {
+		return err
+	}
+
+	profiles, err := loadSparseProfiles(repo)
+	if err != nil {
+		return err
+	}
+	if len(profiles) == 0 {
+		fmt.Println(i18n.T("没有定义稀疏检出组，请在配置的 sparse_profiles 下添加（参见 gwt sparse --help）"))
+		return nil
+	}
+
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{i18n.T("名称"), i18n.T("目录"), i18n.T("说明")})
+	table.SetBorder(true)
+	table.SetAutoWrapText(false)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	for _, name := range names {
+		profile := profiles[name]
+		table.Append([]string{name, strings.Join(profile.Dirs, ", "), profile.Description})
+	}
+	table.Render()
+	return nil
+}
+
+// printSparseDirs 输出 worktree 稀疏检出的目录
+func printSparseDirs(repo *git.Repository, path string) error {
+	if quiet {
+		return nil
+	}
+
+	dirs, err := repo.SparseDirs(path)
+	if errors.Is(err, git.ErrNotSparse) {
+		i18n.Printf("%s 没有启用稀疏检出\n", color.YellowString(path))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	i18n.Printf("%s 稀疏检出的目录:\n", color.YellowString(path))
+	if len(dirs) == 0 {
+		fmt.Printf("  %s\n", color.HiBlackString(formatSparseDirs(dirs)))
+	}
+	for _, dir := range dirs {
+		fmt.Printf("  %s\n", dir)
+	}
+	return nil
+}
+
+// formatSparseDirs 格式化稀疏检出的目录
+func formatSparseDirs(dirs []string) string {
+	if len(dirs) == 0 {
+		return i18n.T("（只有根目录中的文件）")
+	}
+	return strings.Join(dirs, ", ")
+}
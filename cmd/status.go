@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tinsfox/gwt/internal/i18n"
+	"github.com/tinsfox/gwt/pkg/gwt"
+)
+
+var (
+	statusJSON bool
+)
+
+// statusCmd 显示 worktree 的详细状态
+var statusCmd = &cobra.Command{
+	Use:   "status [path|branch]",
+	Short: "显示 worktree 的详细状态",
+	Long: `显示 worktree 的分支、上游分支的领先/落后提交数、锁定状态，
+以及删除后会丢失的工作（修改、未跟踪文件、未推送的提交、stash）。
+
+不指定 worktree 时显示当前所在的 worktree。`,
+	Example: `  # 当前 worktree 的状态
+  gwt status
+
+  # 指定 worktree
+  gwt status feature/login
+
+  # 以 JSON 格式输出
+  gwt status feature/login --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runStatus,
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+
+	statusCmd.Flags().BoolVar(&statusJSON, "json", false, "以 JSON 格式输出")
+}
+
+// statusOutput 是 status --json 的输出格式
+type statusOutput struct {
+	Path        string   `json:"path"`
+	Branch      string   `json:"branch"`
+	Head        string   `json:"head"`
+	Describe    string   `json:"describe,omitempty"`
+	Main        bool     `json:"main"`
+	Locked      bool     `json:"locked"`
+	Scratch     bool     `json:"scratch,omitempty"`
+	LockReason  string   `json:"lock_reason,omitempty"`
+	Upstream    string   `json:"upstream,omitempty"`
+	Sparse      []string `json:"sparse,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Ticket      string   `json:"ticket,omitempty"`
+	Owner       string   `json:"owner,omitempty"`
+	CreatedAt   string   `json:"created_at,omitempty"`
+	AccessedAt  string   `json:"last_accessed,omitempty"`
+	Ahead       int      `json:"ahead"`
+	Behind      int      `json:"behind"`
+	Modified    []string `json:"modified"`
+	Deleted     []string `json:"deleted"`
+	Untracked   []string `json:"untracked"`
+	Unpushed    []string `json:"unpushed"`
+	Stashes     []string `json:"stashes"`
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	target := ""
+	if len(args) > 0 {
+		target = args[0]
+	}
+
+	status, err := newClient(cmd).Status(cmd.Context(), target)
+	if err != nil {
+		return err
+	}
+
+	if statusJSON {
+		return printJSON(newStatusOutput(status))
+	}
+
+	wt := status.Worktree
+	branch := wt.Branch
+	if branch == "" {
+		branch = i18n.T("(分离 HEAD: %s)", wt.Describe)
+	}
+
+	i18n.Printf("路径: %s\n", color.YellowString(wt.Path))
+	i18n.Printf("分支: %s\n", color.CyanString(branch))
+	if wt.LastCommit.Hash != "" {
+		i18n.Printf("提交: %s\n", formatCommitInfo(wt.LastCommit))
+	}
+
+	if status.Upstream != "" {
+		i18n.Printf("上游: %s（领先 %d，落后 %d）\n", status.Upstream, status.Ahead, status.Behind)
+	} else if wt.Branch != "" {
+		i18n.Printf("上游: %s\n", color.YellowString(i18n.T("未设置")))
+	}
+
+	printMetadata(wt.Metadata)
+	printWorktreeTimes(wt)
+
+	if wt.IsScratch {
+		i18n.Printf("临时: %s\n", color.HiBlackString(i18n.T("是，gwt clean 会删除")))
+	}
+
+	if wt.Sparse {
+		i18n.Printf("稀疏检出: %s\n", color.BlueString(formatSparseDirs(wt.SparseDirs)))
+	}
+
+	if wt.IsLocked {
+		reason := ""
+		if wt.LockReason != "" {
+			reason = "（" + wt.LockReason + "）"
+		}
+		i18n.Printf("锁定: %s%s\n", color.YellowString(i18n.T("已锁定")), reason)
+	}
+
+	switch {
+	case status.Changes == nil:
+		i18n.Printf("状态: %s\n", color.RedString(i18n.T("目录不存在")))
+	case status.Changes.HasUnsavedWork():
+		i18n.Printf("状态: %s\n", color.RedString(i18n.T("有未保存的工作")))
+		printWorktreeChanges(status.Changes)
+	default:
+		i18n.Printf("状态: %s\n", color.GreenString(i18n.T("清洁")))
+		if len(status.Changes.Stashes) > 0 {
+			printWorktreeChanges(status.Changes)
+		}
+	}
+
+	return nil
+}
+
+// newStatusOutput 将状态转换为 JSON 输出格式
+func newStatusOutput(status *gwt.Status) statusOutput {
+	wt := status.Worktree
+	out := statusOutput{
+		Path:        wt.Path,
+		Branch:      wt.Branch,
+		Head:        wt.LastCommit.Hash,
+		Describe:    wt.Describe,
+		Main:        wt.IsMain,
+		Locked:      wt.IsLocked,
+		LockReason:  wt.LockReason,
+		Scratch:     wt.IsScratch,
+		Upstream:    status.Upstream,
+		Ahead:       status.Ahead,
+		Behind:      status.Behind,
+		Sparse:      wt.SparseDirs,
+		Description: wt.Metadata.Description,
+		Tags:        wt.Metadata.Tags,
+		Ticket:      wt.Metadata.Ticket,
+		Owner:       wt.Metadata.Owner,
+		Modified:    []string{},
+		Deleted:     []string{},
+		Untracked:   []string{},
+		Unpushed:    []string{},
+		Stashes:     []string{},
+	}
+
+	if !wt.CreatedAt.IsZero() {
+		out.CreatedAt = wt.CreatedAt.Format(time.RFC3339)
+	}
+	if !wt.LastAccessed.IsZero() {
+		out.AccessedAt = wt.LastAccessed.Format(time.RFC3339)
+	}
+
+	if c := status.Changes; c != nil {
+		out.Modified = append(out.Modified, c.Modified...)
+		out.Deleted = append(out.Deleted, c.Deleted...)
+		out.Untracked = append(out.Untracked, c.Untracked...)
+		out.Stashes = append(out.Stashes, c.Stashes...)
+		for _, commit := range c.Unpushed {
+			out.Unpushed = append(out.Unpushed, commit.Hash)
+		}
+	}
+
+	return out
+}
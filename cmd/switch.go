@@ -8,6 +8,14 @@ import (
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/tinsfox/gwt/internal/git"
+	"github.com/tinsfox/gwt/internal/i18n"
+	"github.com/tinsfox/gwt/internal/multiplexer"
+)
+
+var (
+	switchSession  bool
+	switchMux      string
+	switchNoVerify bool
 )
 
 // switchCmd 切换到指定分支的 worktree
@@ -23,13 +31,20 @@ var switchCmd = &cobra.Command{
   gwt switch feature/new-ui
   
   # 如果不存在则自动创建
-  gwt switch hotfix/critical`,
+  gwt switch hotfix/critical
+
+  # 在 tmux/zellij 会话中打开
+  gwt switch feature/new-ui --session`,
 	Args: cobra.ExactArgs(1),
 	RunE: runSwitch,
 }
 
 func init() {
 	rootCmd.AddCommand(switchCmd)
+
+	switchCmd.Flags().BoolVarP(&switchSession, "session", "s", false, "在终端复用器会话中打开，而不是启动子 shell")
+	switchCmd.Flags().StringVar(&switchMux, "mux", "", "终端复用器: tmux, zellij（默认: multiplexer.default）")
+	switchCmd.Flags().BoolVar(&switchNoVerify, "no-verify", false, "跳过分支命名规则检查")
 }
 
 func runSwitch(cmd *cobra.Command, args []string) error {
@@ -38,13 +53,13 @@ func runSwitch(cmd *cobra.Command, args []string) error {
 	// 检查是否在 git 仓库中
 	repo, err := git.OpenRepository(".")
 	if err != nil {
-		return fmt.Errorf("不是 Git 仓库: %w", err)
+		return err
 	}
 
 	// 获取所有 worktree
 	worktrees, err := repo.GetWorktrees()
 	if err != nil {
-		return fmt.Errorf("获取 worktree 列表失败: %w", err)
+		return i18n.Errorf("获取 worktree 列表失败: %w", err)
 	}
 
 	// 查找指定分支的 worktree
@@ -60,37 +75,62 @@ func runSwitch(cmd *cobra.Command, args []string) error {
 	// 如果找到，直接切换到该目录
 	if targetWorktree != nil {
 		if !quiet {
-			fmt.Printf("切换到 worktree:\n")
-			fmt.Printf("  分支: %s\n", color.CyanString(branch))
-			fmt.Printf("  路径: %s\n", color.YellowString(targetWorktree.Path))
+			i18n.Printf("切换到 worktree:\n")
+			i18n.Printf("  分支: %s\n", color.CyanString(branch))
+			i18n.Printf("  路径: %s\n", color.YellowString(targetWorktree.Path))
+		}
+
+		if switchSession {
+			return openInMultiplexer(switchMux, repositoryName(repo), targetWorktree, multiplexer.ModeSession)
 		}
 
 		// 使用 cd 命令切换目录
 		return changeDirectory(targetWorktree.Path)
 	}
 
-	// 没有找到，询问是否创建
-	fmt.Printf("分支 '%s' 的 worktree 不存在。\n", color.CyanString(branch))
-	fmt.Print("是否创建 worktree? [y/N]: ")
+	// 没有找到，先检查分支名再询问是否创建
+	if !switchNoVerify {
+		if err := verifyBranchName(repo, branch); err != nil {
+			return err
+		}
+	}
 
-	var response string
-	fmt.Scanln(&response)
+	i18n.Printf("分支 '%s' 的 worktree 不存在。\n", color.CyanString(branch))
+	create, err := prompter.Confirm(i18n.T("是否创建 worktree?"), false)
+	if err != nil {
+		return err
+	}
+	if !create {
+		return i18n.Errorf("取消操作")
+	}
+
+	branchExists, err := repo.BranchExists(branch)
+	if err != nil {
+		return i18n.Errorf("检查分支失败: %w", err)
+	}
 
-	if response != "y" && response != "yes" {
-		return fmt.Errorf("取消操作")
+	path, err := defaultWorktreePath(repo, branch)
+	if err != nil {
+		return i18n.Errorf("转换路径失败: %w", err)
 	}
 
 	// 创建 worktree
 	worktree, err := repo.CreateWorktree(git.CreateWorktreeOptions{
-		Branch: branch,
-		Path:   branch,
+		Branch:       branch,
+		Path:         path,
+		CreateBranch: !branchExists,
 	})
 	if err != nil {
-		return fmt.Errorf("创建 worktree 失败: %w", err)
+		return err
 	}
 
 	if !quiet {
-		fmt.Printf("✅ worktree 创建成功，路径: %s\n", color.YellowString(worktree.Path))
+		i18n.Printf("✅ worktree 创建成功，路径: %s\n", color.YellowString(worktree.Path))
+	}
+
+	if switchSession {
+		return openInMultiplexer(switchMux, repositoryName(repo),
+			&git.WorktreeInfo{Path: worktree.Path, Branch: worktree.Branch}, multiplexer.ModeSession)
 	}
 
 	// 切换到新创建的目录
@@ -113,7 +153,7 @@ func changeDirectory(path string) error {
 
 	// 执行命令
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("切换目录失败: %w", err)
+		return i18n.Errorf("切换目录失败: %w", err)
 	}
 
 	return nil
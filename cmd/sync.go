@@ -0,0 +1,311 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tinsfox/gwt/internal/git"
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+var (
+	syncNoFetch   bool
+	syncAutostash bool
+	syncStrategy  string
+	syncOnto      string
+	syncSelector  worktreeSelector
+)
+
+// syncCmd 同步所有 worktree
+var syncCmd = &cobra.Command{
+	Use:   "sync [path|branch...]",
+	Short: "将 worktree 同步到上游分支",
+	Long: `获取一次远程更新，然后把每个干净的 worktree 快进或变基到其上游分支
+（没有上游时使用配置的基准分支）。
+
+有未提交修改的 worktree 会被跳过，除非使用 --autostash。
+rebase 出现冲突时该 worktree 保持在 rebase 进行中的状态，并在报告中列出。
+
+可以在配置中按分支设置策略，例如：
+
+  sync:
+    strategy: ff        # ff 或 rebase
+    base: origin/main   # 没有上游时使用
+    autostash: false
+    policies:
+      - match: "feature/*"
+        strategy: rebase
+        onto: origin/main`,
+	Example: `  # 同步所有 worktree
+  gwt sync
+
+  # 只同步 feature 分支，并变基到 origin/main
+  gwt sync --match 'feature/*' --strategy rebase --onto origin/main
+
+  # 有未提交修改时自动 stash
+  gwt sync --autostash`,
+	RunE: runSync,
+}
+
+// syncPolicy 分支同步策略
+type syncPolicy struct {
+	Match    string `mapstructure:"match"`
+	Strategy string `mapstructure:"strategy"`
+	Onto     string `mapstructure:"onto"`
+}
+
+// syncStatus 同步结果状态
+type syncStatus string
+
+const (
+	syncMoved    syncStatus = "moved"
+	syncUpToDate syncStatus = "up-to-date"
+	syncSkipped  syncStatus = "skipped"
+	syncConflict syncStatus = "conflict"
+	syncFailed   syncStatus = "failed"
+)
+
+// syncResult 单个 worktree 的同步结果
+type syncResult struct {
+	Worktree git.WorktreeInfo
+	Status   syncStatus
+	Target   string
+	From     string
+	To       string
+	Reason   string
+}
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+
+	syncCmd.Flags().BoolVar(&syncNoFetch, "no-fetch", false, "不从远程获取更新")
+	syncCmd.Flags().BoolVar(&syncAutostash, "autostash", false, "自动 stash 未提交的修改（默认: sync.autostash）")
+	syncCmd.Flags().StringVar(&syncStrategy, "strategy", "", "同步策略: ff, rebase（覆盖配置）")
+	syncCmd.Flags().StringVar(&syncOnto, "onto", "", "同步到指定引用，而不是上游分支")
+	syncSelector.addFlags(syncCmd)
+}
+
+func runSync(cmd *cobra.Command, args []string) error {
+	if syncStrategy != "" && syncStrategy != "ff" && syncStrategy != "rebase" {
+		return i18n.Errorf("不支持的同步策略: %s", syncStrategy)
+	}
+
+	// 检查是否在 git 仓库中
+	repo, err := git.OpenRepository(".")
+	if err != nil {
+		return err
+	}
+
+	if !syncNoFetch {
+		if !quiet {
+			fmt.Println(i18n.T("获取远程更新..."))
+		}
+		if err := repo.Fetch(""); err != nil {
+			return err
+		}
+	}
+
+	// 获取所有 worktree
+	worktrees, err := repo.GetWorktrees()
+	if err != nil {
+		return i18n.Errorf("获取 worktree 列表失败: %w", err)
+	}
+
+	selected, err := syncSelector.selectWorktrees(repo, worktrees, args)
+	if err != nil {
+		return err
+	}
+
+	var policies []syncPolicy
+	if err := viper.UnmarshalKey("sync.policies", &policies); err != nil {
+		return i18n.Errorf("解析 sync.policies 配置失败: %w", err)
+	}
+
+	autostash := syncAutostash
+	if !cmd.Flags().Changed("autostash") {
+		autostash = viper.GetBool("sync.autostash")
+	}
+
+	var results []syncResult
+	for _, wt := range selected {
+		results = append(results, syncWorktree(repo, wt, policies, autostash))
+	}
+
+	printSyncReport(results)
+
+	if hasSyncProblems(results) {
+		return i18n.Errorf("部分 worktree 同步失败")
+	}
+
+	return nil
+}
+
+// syncWorktree 同步单个 worktree
+func syncWorktree(repo *git.Repository, wt git.WorktreeInfo, policies []syncPolicy, autostash bool) syncResult {
+	result := syncResult{Worktree: wt}
+
+	if wt.Branch == "" {
+		result.Status = syncSkipped
+		result.Reason = i18n.T("分离 HEAD")
+		return result
+	}
+
+	if repo.IsRebaseInProgress(wt.Path) {
+		result.Status = syncSkipped
+		result.Reason = i18n.T("rebase 进行中")
+		return result
+	}
+
+	if wt.IsDirty && !autostash {
+		result.Status = syncSkipped
+		result.Reason = i18n.T("有未提交的修改（使用 --autostash 自动 stash）")
+		return result
+	}
+
+	strategy, target := resolveSyncPolicy(repo, wt, policies)
+	if target == "" {
+		result.Status = syncSkipped
+		result.Reason = i18n.T("没有上游分支，也没有配置 sync.base")
+		return result
+	}
+	result.Target = target
+
+	from, err := repo.RevParse(wt.Path, "HEAD")
+	if err != nil {
+		result.Status = syncFailed
+		result.Reason = err.Error()
+		return result
+	}
+	result.From = from
+
+	if _, err := repo.RevParse(wt.Path, target); err != nil {
+		result.Status = syncFailed
+		result.Reason = err.Error()
+		return result
+	}
+
+	if strategy == "rebase" {
+		err = repo.Rebase(wt.Path, target, autostash)
+	} else {
+		err = repo.FastForward(wt.Path, target, autostash)
+	}
+
+	switch {
+	case errors.Is(err, git.ErrRebaseConflict):
+		result.Status = syncConflict
+		result.Reason = i18n.T("rebase 冲突，请在该目录中解决后运行 git rebase --continue，或运行 git rebase --abort 放弃")
+		return result
+	case errors.Is(err, git.ErrNotFastForward):
+		result.Status = syncSkipped
+		result.Reason = i18n.T("分支已分叉，无法快进（使用 --strategy rebase）")
+		return result
+	case err != nil:
+		result.Status = syncFailed
+		result.Reason = err.Error()
+		return result
+	}
+
+	to, _ := repo.RevParse(wt.Path, "HEAD")
+	result.To = to
+	if to == from {
+		result.Status = syncUpToDate
+	} else {
+		result.Status = syncMoved
+	}
+
+	return result
+}
+
+// resolveSyncPolicy 确定 worktree 的同步策略和目标引用
+func resolveSyncPolicy(repo *git.Repository, wt git.WorktreeInfo, policies []syncPolicy) (string, string) {
+	strategy := viper.GetString("sync.strategy")
+	target := ""
+
+	for _, policy := range policies {
+		if ok, _ := path.Match(policy.Match, wt.Branch); ok {
+			if policy.Strategy != "" {
+				strategy = policy.Strategy
+			}
+			target = policy.Onto
+			break
+		}
+	}
+
+	if syncStrategy != "" {
+		strategy = syncStrategy
+	}
+	if syncOnto != "" {
+		target = syncOnto
+	}
+
+	if target == "" {
+		if upstream, err := repo.Upstream(wt.Path); err == nil && upstream != "" {
+			target = upstream
+		}
+	}
+	if target == "" {
+		target = viper.GetString("sync.base")
+	}
+
+	return strategy, target
+}
+
+// printSyncReport 打印同步报告
+func printSyncReport(results []syncResult) {
+	if quiet && !hasSyncProblems(results) {
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(i18n.T("同步结果:"))
+	for _, result := range results {
+		name := color.CyanString(result.Worktree.Branch)
+		if result.Worktree.Branch == "" {
+			name = color.YellowString(result.Worktree.Path)
+		}
+
+		switch result.Status {
+		case syncMoved:
+			fmt.Printf("  ✅ %s %s..%s (%s)\n", name, shortHash(result.From), shortHash(result.To), result.Target)
+		case syncUpToDate:
+			i18n.Printf("  ✔  %s 已是最新 (%s)\n", name, result.Target)
+		case syncSkipped:
+			i18n.Printf("  ⏭  %s 已跳过: %s\n", name, result.Reason)
+		case syncConflict:
+			fmt.Printf("  ❌ %s %s\n", name, color.RedString(i18n.T("冲突")))
+			i18n.Printf("     路径: %s\n", result.Worktree.Path)
+			fmt.Printf("     %s\n", result.Reason)
+		case syncFailed:
+			i18n.Printf("  ❌ %s 失败: %s\n", name, result.Reason)
+		}
+	}
+
+	counts := make(map[syncStatus]int)
+	for _, result := range results {
+		counts[result.Status]++
+	}
+	i18n.Printf("已更新 %d，已是最新 %d，跳过 %d，冲突 %d，失败 %d\n",
+		counts[syncMoved], counts[syncUpToDate], counts[syncSkipped], counts[syncConflict], counts[syncFailed])
+}
+
+// hasSyncProblems 是否有冲突或失败
+func hasSyncProblems(results []syncResult) bool {
+	for _, result := range results {
+		if result.Status == syncConflict || result.Status == syncFailed {
+			return true
+		}
+	}
+	return false
+}
+
+// shortHash 返回短哈希
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
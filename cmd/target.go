@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/tinsfox/gwt/internal/git"
+	"github.com/tinsfox/gwt/pkg/gwt"
+)
+
+// findWorktree 按分支名、路径或部分匹配查找 worktree
+func findWorktree(worktrees []git.WorktreeInfo, target string) (*git.WorktreeInfo, error) {
+	return gwt.Match(worktrees, target)
+}
+
+// repositoryName 返回仓库名
+//
+// 优先使用主工作区目录名；裸仓库使用公共 git 目录名，
+// 对 repo.git 或 repo/.bare 这样的布局取 repo。
+func repositoryName(repo *git.Repository) string {
+	if repo.MainWorktree != "" {
+		return filepath.Base(repo.MainWorktree)
+	}
+
+	dir := repo.CommonDir
+	switch filepath.Base(dir) {
+	case ".bare", ".git":
+		dir = filepath.Dir(dir)
+	}
+	return strings.TrimSuffix(filepath.Base(dir), ".git")
+}
+
+// defaultWorktreePath 返回分支对应 worktree 的默认路径
+//
+// 裸仓库布局中与其他 worktree 并列存放，否则相对于当前目录。
+func defaultWorktreePath(repo *git.Repository, branch string) (string, error) {
+	if root := repo.LayoutRoot(); root != "" {
+		return filepath.Join(root, branch), nil
+	}
+	return filepath.Abs(branch)
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tinsfox/gwt/internal/git"
+	"github.com/tinsfox/gwt/internal/i18n"
+	"github.com/tinsfox/gwt/internal/templates"
+)
+
+// templateCmd 管理 worktree 模板
+var templateCmd = &cobra.Command{
+	Use:     "template",
+	Aliases: []string{"tpl"},
+	Short:   "管理 worktree 模板",
+	Long: `worktree 模板为常见的任务类型预先定义分支命名、基准分支、路径、
+创建后执行的钩子和默认编辑器，使用 gwt create --template <模板> <名称> 创建。
+
+模板定义在配置的 templates 下。仓库根目录中的 .gwt.yaml 是项目配置，
+随代码提交后整个团队共享；用户配置中的同名模板会覆盖项目配置。
+
+  templates:
+    hotfix:
+      description: 线上紧急修复
+      branch: hotfix/{{.Name}}
+      base: origin/main
+      fetch: true
+      path: ../{{.Repo}}-hotfix-{{.Name}}
+      hooks:
+        - npm ci
+      editor: code
+
+branch 和 path 中可以使用 {{.Name}}、{{.Template}}、{{.Repo}}、{{.Date}}、{{.User}}。
+branch 默认为 <模板名>/{{.Name}}；fetch 为 true 时先从 base 所在的远程获取更新。`,
+}
+
+func init() {
+	rootCmd.AddCommand(templateCmd)
+
+	templateCmd.AddCommand(&cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "列出可用的 worktree 模板",
+		Args:    cobra.NoArgs,
+		RunE:    runTemplateList,
+	})
+}
+
+func runTemplateList(cmd *cobra.Command, args []string) error {
+	repo, err := git.OpenRepository(".")
+	if err != nil {
+		return err
+	}
+
+	all, err := loadTemplates(repo)
+	if err != nil {
+		return err
+	}
+
+	if len(all) == 0 {
+		fmt.Println(i18n.T("没有定义模板，请在配置的 templates 下添加（参见 gwt template --help）"))
+		return nil
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{i18n.T("模板"), i18n.T("分支"), i18n.T("基准"), i18n.T("路径"), i18n.T("钩子"), i18n.T("编辑器"), i18n.T("来源"), i18n.T("说明")})
+	table.SetBorder(true)
+	table.SetRowLine(false)
+	table.SetAutoWrapText(false)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+
+	for _, tpl := range templates.Sorted(all) {
+		branch := tpl.Branch
+		if branch == "" {
+			branch = tpl.Name + "/{{.Name}}"
+		}
+		base := tpl.Base
+		if tpl.Fetch && base != "" {
+			base += " (fetch)"
+		}
+		source := i18n.T("用户配置")
+		if tpl.Source == templates.SourceProject {
+			source = i18n.T("项目配置")
+		}
+
+		table.Append([]string{
+			color.CyanString(tpl.Name),
+			branch,
+			base,
+			tpl.Path,
+			strings.Join(tpl.Hooks, "; "),
+			tpl.Editor,
+			source,
+			tpl.Description,
+		})
+	}
+
+	table.Render()
+	return nil
+}
+
+// loadTemplates 读取项目配置和用户配置中的模板，用户配置覆盖同名的项目模板
+func loadTemplates(repo *git.Repository) (map[string]templates.Template, error) {
+	var sources []map[string]templates.Template
+
+	project, err := loadProjectConfig(repo)
+	if err != nil {
+		return nil, err
+	}
+	if project != nil {
+		list, err := unmarshalTemplates(project, templates.SourceProject)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, list)
+		if project == viper.GetViper() {
+			return templates.Merge(sources...), nil
+		}
+	}
+
+	list, err := unmarshalTemplates(viper.GetViper(), templates.SourceUser)
+	if err != nil {
+		return nil, err
+	}
+	sources = append(sources, list)
+
+	return templates.Merge(sources...), nil
+}
+
+// unmarshalTemplates 解析配置中的 templates 并标记来源
+func unmarshalTemplates(config *viper.Viper, source string) (map[string]templates.Template, error) {
+	var list map[string]templates.Template
+	if err := config.UnmarshalKey("templates", &list); err != nil {
+		return nil, i18n.Errorf("解析 templates 配置失败: %w", err)
+	}
+
+	for name, tpl := range list {
+		tpl.Name = name
+		tpl.Source = source
+		list[name] = tpl
+	}
+	return list, nil
+}
+
+// findTemplate 查找指定名称的模板
+func findTemplate(repo *git.Repository, name string) (*templates.Template, error) {
+	all, err := loadTemplates(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	tpl, ok := all[name]
+	if !ok {
+		names := make([]string, 0, len(all))
+		for _, t := range templates.Sorted(all) {
+			names = append(names, t.Name)
+		}
+		if len(names) == 0 {
+			return nil, i18n.Errorf("模板不存在: %s（没有定义任何模板）", name)
+		}
+		return nil, i18n.Errorf("模板不存在: %s（可用: %s）", name, strings.Join(names, ", "))
+	}
+	return &tpl, nil
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tinsfox/gwt/internal/git"
+	"github.com/tinsfox/gwt/internal/i18n"
+	"github.com/tinsfox/gwt/internal/multiplexer"
+)
+
+var (
+	tmuxWindow bool
+	tmuxMux    string
+)
+
+// tmuxCmd 在终端复用器中打开 worktree
+var tmuxCmd = &cobra.Command{
+	Use:     "tmux <branch|path>",
+	Aliases: []string{"mux"},
+	Short:   "在 tmux/zellij 会话中打开 worktree",
+	Long: `在终端复用器中为 worktree 创建或附加会话，会话以仓库名和分支名命名，
+工作目录为 worktree 路径。
+
+在 tmux 内运行时会切换客户端 (switch-client)，在 tmux 外运行时会附加会话 (attach)。
+可以通过配置 multiplexer.layout 定义窗格布局，例如：
+
+  multiplexer:
+    default: tmux
+    mode: session
+    layout:
+      arrange: main-vertical
+      panes:
+        - command: nvim .
+        - command: go test ./...
+          split: horizontal
+        - command: ""`,
+	Example: `  # 为分支创建或附加 tmux 会话
+  gwt tmux feature/new-ui
+
+  # 在当前会话中以窗口形式打开
+  gwt tmux feature/new-ui -w
+
+  # 使用 zellij
+  gwt tmux feature/new-ui --mux zellij
+  gwt zellij feature/new-ui`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTmux,
+}
+
+var zellijCmd = &cobra.Command{
+	Use:   "zellij <branch|path>",
+	Short: "在 zellij 会话中打开 worktree",
+	Long:  "快捷命令，等同于 'gwt tmux <branch|path> --mux zellij'",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tmuxMux = "zellij"
+		return runTmux(cmd, args)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tmuxCmd)
+	rootCmd.AddCommand(zellijCmd)
+
+	tmuxCmd.Flags().BoolVarP(&tmuxWindow, "window", "w", false, "以窗口/标签页形式打开，而不是独立会话")
+	tmuxCmd.Flags().StringVar(&tmuxMux, "mux", "", "终端复用器: tmux, zellij（默认: multiplexer.default）")
+	zellijCmd.Flags().BoolVarP(&tmuxWindow, "window", "w", false, "以标签页形式打开，而不是独立会话")
+}
+
+func runTmux(cmd *cobra.Command, args []string) error {
+	target := args[0]
+
+	// 检查是否在 git 仓库中
+	repo, err := git.OpenRepository(".")
+	if err != nil {
+		return err
+	}
+
+	// 获取所有 worktree
+	worktrees, err := repo.GetWorktrees()
+	if err != nil {
+		return i18n.Errorf("获取 worktree 列表失败: %w", err)
+	}
+
+	wt, err := findWorktree(worktrees, target)
+	if err != nil {
+		return err
+	}
+
+	mode := multiplexer.Mode(viper.GetString("multiplexer.mode"))
+	if tmuxWindow {
+		mode = multiplexer.ModeWindow
+	}
+
+	return openInMultiplexer(tmuxMux, repositoryName(repo), wt, mode)
+}
+
+// openInMultiplexer 在终端复用器中打开 worktree
+func openInMultiplexer(name, repoName string, wt *git.WorktreeInfo, mode multiplexer.Mode) error {
+	if name == "" {
+		// 已在某个复用器中时优先使用它
+		if current := multiplexer.Detect(); current != nil {
+			name = current.Name()
+		} else {
+			name = viper.GetString("multiplexer.default")
+		}
+	}
+
+	mux, err := multiplexer.Get(name)
+	if err != nil {
+		return err
+	}
+
+	var layout multiplexer.Layout
+	if err := viper.UnmarshalKey("multiplexer.layout", &layout); err != nil {
+		return i18n.Errorf("解析 multiplexer.layout 配置失败: %w", err)
+	}
+
+	branch := wt.Branch
+	if branch == "" {
+		branch = "detached"
+	}
+
+	dir, err := filepath.Abs(wt.Path)
+	if err != nil {
+		return i18n.Errorf("转换路径失败: %w", err)
+	}
+
+	if mode != multiplexer.ModeWindow {
+		mode = multiplexer.ModeSession
+	}
+
+	t := multiplexer.Target{
+		Session: repoName,
+		Window:  branch,
+		Dir:     dir,
+		Mode:    mode,
+		Layout:  layout,
+	}
+	if mode == multiplexer.ModeSession {
+		t.Session = repoName + "/" + branch
+	}
+
+	if !quiet {
+		i18n.Printf("在 %s 中打开 worktree:\n", mux.Name())
+		i18n.Printf("  分支: %s\n", color.CyanString(branch))
+		i18n.Printf("  路径: %s\n", color.YellowString(dir))
+		i18n.Printf("  会话: %s\n", multiplexer.SanitizeName(t.Session))
+	}
+
+	if err := mux.Open(t); err != nil {
+		return i18n.Errorf("打开 %s 失败: %w", mux.Name(), err)
+	}
+
+	return nil
+}
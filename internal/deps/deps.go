@@ -0,0 +1,293 @@
+// Package deps 在新建 worktree 时复用其他 worktree 中已经安装好的依赖目录
+package deps
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+// Method 是复制依赖目录的方式
+type Method string
+
+const (
+	// MethodAuto 优先使用 reflink，文件系统不支持时退回普通复制
+	MethodAuto Method = "auto"
+	// MethodReflink 使用写时复制（Linux 的 reflink，macOS 的 clonefile），不额外占用空间
+	MethodReflink Method = "reflink"
+	// MethodHardlink 使用硬链接，文件内容在 worktree 之间共享，原地修改会互相影响
+	MethodHardlink Method = "hardlink"
+	// MethodCopy 逐个复制文件
+	MethodCopy Method = "copy"
+)
+
+// ParseMethod 解析复制方式，为空时使用 MethodAuto
+func ParseMethod(s string) (Method, error) {
+	switch Method(s) {
+	case "", MethodAuto:
+		return MethodAuto, nil
+	case MethodReflink, MethodHardlink, MethodCopy:
+		return Method(s), nil
+	}
+	return "", i18n.Errorf("不支持的复制方式: %s（支持: auto, reflink, hardlink, copy）", s)
+}
+
+// Dir 是可以复用的依赖目录
+type Dir struct {
+	// Name 是配置中的名称，来自 deps.dirs 的键
+	Name string `mapstructure:"-"`
+	// Path 是依赖目录相对于 worktree 根目录的路径
+	Path string `mapstructure:"path"`
+	// Lockfiles 是决定依赖内容的锁文件，相对于依赖目录的上级目录；
+	// 只有锁文件完全一致时才会复用
+	Lockfiles []string `mapstructure:"lockfiles"`
+	// Method 覆盖全局的复制方式
+	Method string `mapstructure:"method"`
+	// Disabled 为 true 时不复用这个目录
+	Disabled bool `mapstructure:"disabled"`
+}
+
+// DefaultDirs 返回内置的依赖目录
+//
+// 不包括 Python 的虚拟环境：bin/activate 和脚本的 shebang 中写死了所在 worktree 的绝对路径，
+// 复制后仍然使用原 worktree 的解释器和包。
+func DefaultDirs() map[string]Dir {
+	return map[string]Dir{
+		"node": {
+			Path:      "node_modules",
+			Lockfiles: []string{"package-lock.json", "yarn.lock", "pnpm-lock.yaml", "bun.lockb"},
+		},
+		"go": {
+			Path:      "vendor",
+			Lockfiles: []string{"go.mod", "go.sum"},
+		},
+		"rust": {
+			Path:      "target",
+			Lockfiles: []string{"Cargo.lock"},
+		},
+	}
+}
+
+// Sorted 返回按名称排序、已启用的依赖目录
+func Sorted(dirs map[string]Dir) []Dir {
+	var result []Dir
+	for name, dir := range dirs {
+		if dir.Disabled || dir.Path == "" {
+			continue
+		}
+		dir.Name = name
+		result = append(result, dir)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// LockHash 计算 root 中依赖目录对应锁文件的哈希
+//
+// 哈希包括存在的锁文件的名称和内容；一个锁文件都没有时返回空字符串，
+// 这时无法确认依赖是否一致，不应复用。
+func (d Dir) LockHash(root string) (string, error) {
+	base := filepath.Dir(filepath.Join(root, d.Path))
+
+	h := sha256.New()
+	found := false
+	for _, name := range d.Lockfiles {
+		f, err := os.Open(filepath.Join(base, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+
+		found = true
+		io.WriteString(h, name+"\x00")
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if !found {
+		return "", nil
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Exists 判断 root 中是否有这个依赖目录
+func (d Dir) Exists(root string) bool {
+	info, err := os.Stat(filepath.Join(root, d.Path))
+	return err == nil && info.IsDir()
+}
+
+// Clone 把 src 目录复制为 dst，返回实际使用的方式
+//
+// dst 不能已经存在。使用 MethodAuto 时先尝试 reflink，失败后退回普通复制；
+// MethodHardlink 无法创建硬链接（例如跨文件系统）时同样退回普通复制。
+// 复制失败时会删除已经复制的部分。
+func Clone(src, dst string, method Method) (Method, error) {
+	if _, err := os.Lstat(dst); err == nil {
+		return "", i18n.Errorf("目标目录已存在: %s", dst)
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return "", err
+	}
+
+	if method == MethodAuto {
+		if err := reflink(src, dst); err == nil {
+			return MethodReflink, nil
+		}
+		os.RemoveAll(dst)
+		method = MethodCopy
+	}
+
+	var err error
+	switch method {
+	case MethodReflink:
+		err = reflink(src, dst)
+	case MethodHardlink:
+		if err = copyTree(src, dst, os.Link); err != nil {
+			os.RemoveAll(dst)
+			method = MethodCopy
+			err = copyTree(src, dst, copyFile)
+		}
+	default:
+		err = copyTree(src, dst, copyFile)
+	}
+	if err != nil {
+		os.RemoveAll(dst)
+		return "", err
+	}
+	return method, nil
+}
+
+// reflink 使用文件系统的写时复制复制目录，文件系统不支持时返回错误
+func reflink(src, dst string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "linux":
+		cmd = exec.Command("cp", "-a", "--reflink=always", src, dst)
+	case "darwin":
+		cmd = exec.Command("cp", "-c", "-R", "-p", src, dst)
+	default:
+		return i18n.Errorf("当前系统不支持 reflink")
+	}
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return i18n.Errorf("reflink 失败: %s", string(output))
+	}
+	return nil
+}
+
+// copyTree 复制目录结构和符号链接，普通文件使用 file 复制
+func copyTree(src, dst string, file func(src, dst string) error) error {
+	// 目录先以可写权限创建，复制完成后再恢复原来的权限
+	modes := make(map[string]os.FileMode)
+
+	err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case d.IsDir():
+			modes[target] = info.Mode().Perm()
+			return os.MkdirAll(target, info.Mode().Perm()|0700)
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case info.Mode().IsRegular():
+			return file(path, target)
+		}
+		// 套接字、管道等特殊文件不复制
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for dir, mode := range modes {
+		if err := os.Chmod(dir, mode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyFile 复制文件内容和权限位
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// Config 是 deps 配置
+type Config struct {
+	// Enabled 为 true 时 gwt create 会复用依赖目录
+	Enabled bool `mapstructure:"enabled"`
+	// Method 是默认的复制方式，为空时使用 auto
+	Method string `mapstructure:"method"`
+	// Dirs 以名称为键，添加依赖目录或修改内置的依赖目录
+	Dirs map[string]Dir `mapstructure:"dirs"`
+}
+
+// Merge 把配置中的依赖目录合并到内置的依赖目录上，返回按名称排序、已启用的目录
+//
+// 配置中与内置目录同名的条目只覆盖设置了的字段。
+func (c Config) Merge() []Dir {
+	dirs := DefaultDirs()
+	for name, dir := range c.Dirs {
+		if base, ok := dirs[name]; ok {
+			if dir.Path == "" {
+				dir.Path = base.Path
+			}
+			if len(dir.Lockfiles) == 0 {
+				dir.Lockfiles = base.Lockfiles
+			}
+		}
+		dirs[name] = dir
+	}
+	return Sorted(dirs)
+}
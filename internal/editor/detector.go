@@ -1,11 +1,12 @@
 package editor
 
 import (
-	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+
+	"github.com/tinsfox/gwt/internal/i18n"
 )
 
 // EditorInfo 表示编辑器信息
@@ -23,7 +24,7 @@ func DetectEditor(editorName string) (*EditorInfo, error) {
 	// 获取编辑器配置
 	editorConfig := getEditorConfig(editorName)
 	if editorConfig == nil {
-		return nil, fmt.Errorf("不支持的编辑器: %s", editorName)
+		return nil, i18n.Errorf("不支持的编辑器: %s", editorName)
 	}
 
 	// 检查编辑器是否可用
@@ -33,12 +34,21 @@ func DetectEditor(editorName string) (*EditorInfo, error) {
 		if fallback != nil {
 			return fallback, nil
 		}
-		return nil, fmt.Errorf("编辑器 '%s' 未安装或不在 PATH 中", editorName)
+		return nil, i18n.Errorf("编辑器 '%s' 未安装或不在 PATH 中", editorName)
 	}
 
 	return editorConfig, nil
 }
 
+// IsAvailable 检查编辑器是否受支持并已安装，不会回退到其他编辑器
+func IsAvailable(editorName string) (bool, error) {
+	editorConfig := getEditorConfig(editorName)
+	if editorConfig == nil {
+		return false, i18n.Errorf("不支持的编辑器: %s", editorName)
+	}
+	return isEditorAvailable(editorConfig.Command), nil
+}
+
 // getEditorConfig 获取编辑器配置
 func getEditorConfig(editorName string) *EditorInfo {
 	configs := getEditorConfigs()
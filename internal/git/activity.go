@@ -0,0 +1,42 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// LastActivity 返回 worktree 最近的活动时间：最近访问时间和最后提交时间中较晚的一个
+func (wt WorktreeInfo) LastActivity() time.Time {
+	if wt.LastAccessed.After(wt.LastCommit.Date) {
+		return wt.LastAccessed
+	}
+	return wt.LastCommit.Date
+}
+
+// adminCreatedAt 根据管理目录估计不是由 gwt 创建的 worktree 的创建时间
+//
+// 使用 git 创建 worktree 时写入、之后不再修改的文件的修改时间：链接 worktree 为
+// commondir，主工作区为 description。不使用目录本身的时间，git 每次写入索引都会改变它。
+func adminCreatedAt(adminDir string) time.Time {
+	for _, name := range []string{"commondir", "description"} {
+		if info, err := os.Stat(filepath.Join(adminDir, name)); err == nil {
+			return info.ModTime()
+		}
+	}
+	return time.Time{}
+}
+
+// adminAccessedAt 返回 worktree 中最近一次 git 操作的时间
+//
+// 取管理目录中索引、HEAD 和 HEAD 日志的最晚修改时间：在 worktree 中暂存、提交、
+// 切换分支等操作都会更新它们。gwt 自己执行的只读命令不会更新，见 isWorktreeDirty。
+func adminAccessedAt(adminDir string) time.Time {
+	var latest time.Time
+	for _, name := range []string{"index", "HEAD", filepath.Join("logs", "HEAD")} {
+		if info, err := os.Stat(filepath.Join(adminDir, name)); err == nil && info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest
+}
@@ -0,0 +1,151 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+// ErrApplyConflict 表示补丁无法干净地应用到 worktree
+var ErrApplyConflict = i18n.Error("修改无法干净地应用")
+
+// ApplyConflictError 表示补丁在 Files 上有冲突
+type ApplyConflictError struct {
+	Files []string
+	// Merged 为 true 时补丁已经以三方合并的方式应用，冲突标记写入了 Files；
+	// 为 false 时没有修改任何文件
+	Merged bool
+	Err    error
+}
+
+func (e *ApplyConflictError) Error() string {
+	if len(e.Files) == 0 {
+		return ErrApplyConflict.Error()
+	}
+	return i18n.T("修改无法干净地应用，冲突的文件: %s", strings.Join(e.Files, ", "))
+}
+
+// Unwrap 返回 ErrApplyConflict 以及底层的命令错误
+func (e *ApplyConflictError) Unwrap() []error {
+	return []error{ErrApplyConflict, e.Err}
+}
+
+// ApplyOptions git apply 的选项
+type ApplyOptions struct {
+	// Index 同时更新暂存区
+	Index bool
+	// ThreeWay 无法直接应用时尝试三方合并，冲突以冲突标记写入文件
+	ThreeWay bool
+	// Reverse 反向应用补丁
+	Reverse bool
+	// Check 只检查能否应用，不修改任何文件
+	Check bool
+}
+
+var (
+	applyErrorPattern    = regexp.MustCompile(`(?m)^error: (.+): (?:patch does not apply|already exists in (?:working directory|index)|does not exist in index|does not match index|No such file or directory)$`)
+	applyConflictPattern = regexp.MustCompile(`(?m)^U (.+)$`)
+)
+
+// ChangesPatch 生成 worktree 中未提交修改的二进制补丁，以及涉及的文件
+//
+// stagedOnly 为 true 时只包括暂存区中的修改，否则包括所有未提交的修改和
+// 未跟踪文件（被 .gitignore 忽略的文件除外）。paths 限制包括的路径，相对于
+// worktree 根目录。没有修改时返回空补丁。
+func (r *Repository) ChangesPatch(path string, stagedOnly bool, paths []string) ([]byte, []string, error) {
+	args := []string{"diff", "--binary", "--full-index", "--no-renames", "--no-color", "--no-ext-diff"}
+	if stagedOnly {
+		args = append(args, "--cached", "HEAD")
+	} else {
+		tree, err := r.SnapshotTree(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		args = append(args, "HEAD", tree)
+	}
+	args = append(args, "--")
+	args = append(args, paths...)
+
+	patch, err := r.run(path, args...)
+	if err != nil {
+		return nil, nil, i18n.Errorf("生成补丁失败: %w", err)
+	}
+
+	// --name-only 与补丁使用相同的参数，保证文件列表一致
+	nameArgs := append([]string{args[0], "--name-only"}, args[1:]...)
+	output, err := r.output(path, nameArgs...)
+	if err != nil {
+		return nil, nil, i18n.Errorf("生成补丁失败: %w", err)
+	}
+
+	var files []string
+	if output != "" {
+		files = strings.Split(output, "\n")
+	}
+	return patch, files, nil
+}
+
+// ApplyPatch 把补丁应用到 dir 中的 worktree
+//
+// git apply 要么应用全部修改，要么不修改任何文件。无法应用时返回
+// *ApplyConflictError；使用 ThreeWay 时冲突会以冲突标记写入文件。
+func (r *Repository) ApplyPatch(dir string, patch []byte, opts ApplyOptions) error {
+	args := []string{"apply"}
+	if opts.Index {
+		args = append(args, "--index")
+	}
+	if opts.ThreeWay {
+		args = append(args, "--3way")
+	}
+	if opts.Reverse {
+		args = append(args, "--reverse")
+	}
+	if opts.Check {
+		args = append(args, "--check")
+	}
+
+	_, err := r.runCommand(Command{Dir: dir, Args: args, Stdin: bytes.NewReader(patch)})
+	if err == nil {
+		return nil
+	}
+
+	var cmdErr *CommandError
+	if !errors.As(err, &cmdErr) {
+		return i18n.Errorf("应用补丁失败: %w", err)
+	}
+
+	if opts.ThreeWay {
+		if files := applyFiles(applyConflictPattern, cmdErr.Stderr); len(files) > 0 {
+			return &ApplyConflictError{Files: files, Merged: true, Err: err}
+		}
+	}
+	if files := applyFiles(applyErrorPattern, cmdErr.Stderr); len(files) > 0 {
+		return &ApplyConflictError{Files: files, Err: err}
+	}
+	return i18n.Errorf("应用补丁失败: %w", err)
+}
+
+// ResetPaths 把 dir 中 worktree 暂存区里的 files 恢复为 HEAD 的内容，不修改文件
+func (r *Repository) ResetPaths(dir string, files []string) error {
+	args := append([]string{"reset", "-q", "--"}, files...)
+	if _, err := r.run(dir, args...); err != nil {
+		return i18n.Errorf("重置暂存区失败: %w", err)
+	}
+	return nil
+}
+
+// applyFiles 从 git apply 的错误输出中提取文件名，去除重复
+func applyFiles(pattern *regexp.Regexp, stderr string) []string {
+	seen := make(map[string]bool)
+	var files []string
+	for _, m := range pattern.FindAllStringSubmatch(stderr, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			files = append(files, m[1])
+		}
+	}
+	return files
+}
@@ -0,0 +1,99 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+// BareDirName 是 worktree-per-branch 布局中裸仓库的目录名
+const BareDirName = ".bare"
+
+// CloneBare 以 worktree-per-branch 布局克隆仓库
+//
+// 布局如下：
+//
+//	dir/
+//	  .bare/   裸仓库
+//	  .git     gitfile，指向 .bare
+//	  main/    默认分支的 worktree
+//
+// 返回打开的仓库以及默认分支名。runner 为 nil 时使用 DefaultRunner。
+func CloneBare(ctx context.Context, runner Runner, url, dir string) (*Repository, string, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, "", i18n.Errorf("转换路径失败: %w", err)
+	}
+
+	if entries, err := os.ReadDir(absDir); err == nil && len(entries) > 0 {
+		return nil, "", i18n.Errorf("目录已存在且不为空: %s", absDir)
+	}
+
+	if err := os.MkdirAll(absDir, 0755); err != nil {
+		return nil, "", i18n.Errorf("创建目录失败: %w", err)
+	}
+
+	if runner == nil {
+		runner = DefaultRunner
+	}
+	// 仓库尚不存在，先用未初始化的 Repository 执行 git 命令
+	bootstrap := &Repository{runner: runner, ctx: ctx}
+
+	bareDir := filepath.Join(absDir, BareDirName)
+	if _, err := bootstrap.run(absDir, "clone", "--bare", url, bareDir); err != nil {
+		return nil, "", i18n.Errorf("克隆仓库失败: %w", err)
+	}
+
+	// 让 git 命令在布局根目录中也能找到仓库
+	gitFile := filepath.Join(absDir, ".git")
+	if err := os.WriteFile(gitFile, []byte("gitdir: ./"+BareDirName+"\n"), 0644); err != nil {
+		return nil, "", i18n.Errorf("写入 .git 文件失败: %w", err)
+	}
+
+	// git clone --bare 不会配置远程跟踪分支，补上 fetch refspec 后重新获取
+	if _, err := bootstrap.run(bareDir, "config", "remote.origin.fetch", "+refs/heads/*:refs/remotes/origin/*"); err != nil {
+		return nil, "", i18n.Errorf("配置 fetch refspec 失败: %w", err)
+	}
+	if _, err := bootstrap.run(bareDir, "fetch", "origin"); err != nil {
+		return nil, "", i18n.Errorf("获取远程分支失败: %w", err)
+	}
+	if _, err := bootstrap.run(bareDir, "remote", "set-head", "origin", "--auto"); err != nil {
+		return nil, "", i18n.Errorf("设置 origin/HEAD 失败: %w", err)
+	}
+
+	defaultBranch, err := bootstrap.output(bareDir, "symbolic-ref", "--short", "HEAD")
+	if err != nil {
+		return nil, "", i18n.Errorf("获取默认分支失败: %w", err)
+	}
+
+	repo, err := Open(ctx, absDir, runner)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if _, err := repo.CreateWorktree(CreateWorktreeOptions{
+		Branch: defaultBranch,
+		Path:   filepath.Join(absDir, defaultBranch),
+	}); err != nil {
+		return nil, "", err
+	}
+
+	if _, err := bootstrap.run(bareDir, "branch", "--set-upstream-to=origin/"+defaultBranch, defaultBranch); err != nil {
+		return nil, "", i18n.Errorf("设置上游分支失败: %w", err)
+	}
+
+	return repo, defaultBranch, nil
+}
+
+// LayoutRoot 返回新 worktree 的默认父目录
+//
+// 裸仓库没有主工作区，worktree 与裸仓库目录并列存放；
+// 普通仓库返回空字符串，由调用方使用当前目录。
+func (r *Repository) LayoutRoot() string {
+	if !r.IsBare {
+		return ""
+	}
+	return filepath.Dir(r.CommonDir)
+}
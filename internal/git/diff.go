@@ -0,0 +1,139 @@
+package git
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+// DiffOptions git diff 的输出选项
+type DiffOptions struct {
+	Stat     bool
+	NameOnly bool
+	Color    bool
+	// Paths 限制比较的路径
+	Paths []string
+}
+
+// SnapshotTree 把 worktree 当前的文件（包括未提交的修改和未跟踪文件）写成 tree 对象
+//
+// 使用索引的临时副本执行 git add -A，不会改变 worktree 的暂存区；
+// 被 .gitignore 忽略的文件不包括在内。
+func (r *Repository) SnapshotTree(path string) (string, error) {
+	indexPath, err := r.output(path, "rev-parse", "--git-path", "index")
+	if err != nil {
+		return "", i18n.Errorf("获取索引文件路径失败: %w", err)
+	}
+	indexPath = resolvePath(path, indexPath)
+
+	tmp, err := os.CreateTemp("", "gwt-index-*")
+	if err != nil {
+		return "", i18n.Errorf("创建临时索引失败: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if index, err := os.Open(indexPath); err == nil {
+		_, err = io.Copy(tmp, index)
+		index.Close()
+		if err != nil {
+			tmp.Close()
+			return "", i18n.Errorf("复制索引失败: %w", err)
+		}
+	}
+	tmp.Close()
+
+	env := []string{"GIT_INDEX_FILE=" + tmp.Name()}
+	if _, err := r.runCommand(Command{Dir: path, Args: []string{"add", "-A"}, Env: env}); err != nil {
+		return "", i18n.Errorf("读取 worktree 文件失败: %w", err)
+	}
+
+	output, err := r.runCommand(Command{Dir: path, Args: []string{"write-tree"}, Env: env})
+	if err != nil {
+		return "", i18n.Errorf("生成 worktree 快照失败: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// Diff 比较两个提交或 tree，输出写入 out
+func (r *Repository) Diff(from, to string, opts DiffOptions, out io.Writer) error {
+	args := []string{"diff"}
+	if opts.Color {
+		args = append(args, "--color=always")
+	}
+	if opts.Stat {
+		args = append(args, "--stat")
+	}
+	if opts.NameOnly {
+		args = append(args, "--name-only")
+	}
+	args = append(args, from, to, "--")
+	args = append(args, opts.Paths...)
+
+	if _, err := r.runCommand(Command{Args: args, Stdout: out}); err != nil {
+		return i18n.Errorf("比较失败: %w", err)
+	}
+	return nil
+}
+
+// DiffTool 使用 git 配置的 difftool 以目录模式比较两个提交或 tree
+func (r *Repository) DiffTool(from, to string, paths []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	args := append([]string{"difftool", "--dir-diff", from, to, "--"}, paths...)
+
+	if _, err := r.runCommand(Command{Args: args, Stdin: stdin, Stdout: stdout, Stderr: stderr}); err != nil {
+		return i18n.Errorf("启动 difftool 失败: %w", err)
+	}
+	return nil
+}
+
+// MergeBase 获取两个提交的共同祖先，没有共同祖先时返回空字符串
+func (r *Repository) MergeBase(a, b string) string {
+	output, err := r.output(r.Path, "merge-base", a, b)
+	if err != nil {
+		return ""
+	}
+	return output
+}
+
+// CommitDistance 返回只在 a 或只在 b 中的提交总数，数值越小两个提交越接近
+func (r *Repository) CommitDistance(a, b string) (int, error) {
+	output, err := r.output(r.Path, "rev-list", "--count", a+"..."+b)
+	if err != nil {
+		return 0, i18n.Errorf("获取提交差异失败: %w", err)
+	}
+	return strconv.Atoi(output)
+}
+
+// UniqueCommits 获取只在 a 中和只在 b 中的提交
+func (r *Repository) UniqueCommits(a, b string) ([]CommitInfo, []CommitInfo, error) {
+	output, err := r.run(r.Path, "log", "--left-right", "--pretty=format:%m%x00%H%x00%an%x00%ai%x00%s", a+"..."+b)
+	if err != nil {
+		return nil, nil, i18n.Errorf("获取提交差异失败: %w", err)
+	}
+
+	var left, right []CommitInfo
+	for _, line := range strings.Split(string(output), "\n") {
+		parts := strings.SplitN(line, "\x00", 5)
+		if len(parts) < 5 {
+			continue
+		}
+
+		date, _ := time.Parse("2006-01-02 15:04:05 -0700", parts[3])
+		commit := CommitInfo{
+			Hash:    parts[1],
+			Author:  parts[2],
+			Date:    date,
+			Subject: parts[4],
+		}
+		if parts[0] == "<" {
+			left = append(left, commit)
+		} else {
+			right = append(right, commit)
+		}
+	}
+
+	return left, right, nil
+}
@@ -0,0 +1,149 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+// WorktreeAdmin 表示公共 git 目录下 worktrees/<name> 中的 worktree 管理目录
+type WorktreeAdmin struct {
+	// Name 是管理目录名
+	Name string
+	// Dir 是管理目录的路径
+	Dir string
+	// Path 是 gitdir 文件记录的 worktree 根目录，无法读取时为空
+	Path string
+	// Locked 表示存在 locked 文件
+	Locked bool
+}
+
+// WorktreeAdmins 获取所有链接 worktree 的管理目录
+func (r *Repository) WorktreeAdmins() ([]WorktreeAdmin, error) {
+	root := filepath.Join(r.CommonDir, "worktrees")
+	entries, err := os.ReadDir(root)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, i18n.Errorf("读取 worktree 管理目录失败: %w", err)
+	}
+
+	var admins []WorktreeAdmin
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		admin := WorktreeAdmin{
+			Name: entry.Name(),
+			Dir:  filepath.Join(root, entry.Name()),
+		}
+		if data, err := os.ReadFile(filepath.Join(admin.Dir, "gitdir")); err == nil {
+			// gitdir 记录的是 worktree 中 .git 文件的路径
+			admin.Path = filepath.Dir(resolvePath(admin.Dir, strings.TrimSpace(string(data))))
+		}
+		if _, err := os.Stat(filepath.Join(admin.Dir, "locked")); err == nil {
+			admin.Locked = true
+		}
+		admins = append(admins, admin)
+	}
+
+	return admins, nil
+}
+
+// CheckLink 检查 worktree 中的 .git 文件是否指回管理目录
+//
+// worktree 目录不存在时返回 nil，由 PrunableWorktrees 报告。
+func (a WorktreeAdmin) CheckLink() error {
+	if a.Path == "" {
+		return i18n.Errorf("%s 缺少 gitdir 文件", a.Dir)
+	}
+	if _, err := os.Stat(a.Path); os.IsNotExist(err) {
+		return nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(a.Path, ".git"))
+	if err != nil {
+		return i18n.Errorf("%s 中没有 .git 文件", a.Path)
+	}
+
+	content := strings.TrimSpace(string(data))
+	if !strings.HasPrefix(content, "gitdir:") {
+		return i18n.Errorf("%s/.git 不是指向管理目录的 gitdir 文件", a.Path)
+	}
+
+	target := resolvePath(a.Path, strings.TrimSpace(strings.TrimPrefix(content, "gitdir:")))
+	if !samePath(target, a.Dir) {
+		return i18n.Errorf("%s/.git 指向 %s，而不是 %s", a.Path, target, a.Dir)
+	}
+	return nil
+}
+
+// samePath 比较两个路径在解析符号链接后是否相同
+func samePath(a, b string) bool {
+	if resolved, err := filepath.EvalSymlinks(a); err == nil {
+		a = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(b); err == nil {
+		b = resolved
+	}
+	return a == b
+}
+
+// RepairWorktrees 使用 git worktree repair 修复 worktree 与管理目录之间的链接
+func (r *Repository) RepairWorktrees(paths ...string) error {
+	args := append([]string{"worktree", "repair"}, paths...)
+	if _, err := r.run(r.Path, args...); err != nil {
+		return i18n.Errorf("修复 worktree 链接失败: %w", err)
+	}
+	return nil
+}
+
+// RemoveWorktreeAdmin 删除单个 worktree 的管理目录，相当于只清理这一个 worktree
+func (r *Repository) RemoveWorktreeAdmin(admin WorktreeAdmin) error {
+	if admin.Locked {
+		return i18n.Errorf("%w: %s", ErrLocked, admin.Path)
+	}
+	if err := os.RemoveAll(admin.Dir); err != nil {
+		return i18n.Errorf("删除 worktree 管理目录失败: %w", err)
+	}
+	return nil
+}
+
+// LockFile 表示 git 留下的 .lock 文件
+type LockFile struct {
+	Path    string
+	ModTime time.Time
+}
+
+// LockFiles 获取公共 git 目录和各 worktree 管理目录中的 .lock 文件
+//
+// git 进程异常退出时会留下这些文件，导致之后的命令报错。
+func (r *Repository) LockFiles() ([]LockFile, error) {
+	dirs := []string{r.CommonDir}
+	admins, err := r.WorktreeAdmins()
+	if err != nil {
+		return nil, err
+	}
+	for _, admin := range admins {
+		dirs = append(dirs, admin.Dir)
+	}
+
+	var locks []LockFile
+	for _, dir := range dirs {
+		matches, _ := filepath.Glob(filepath.Join(dir, "*.lock"))
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil || info.IsDir() {
+				continue
+			}
+			locks = append(locks, LockFile{Path: match, ModTime: info.ModTime()})
+		}
+	}
+
+	return locks, nil
+}
@@ -0,0 +1,157 @@
+package git
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+var (
+	// ErrNotRepository 表示目录不在 Git 仓库中
+	ErrNotRepository = i18n.Error("不是 Git 仓库")
+	// ErrNotFound 表示没有找到指定的 worktree
+	ErrNotFound = i18n.Error("未找到 worktree")
+	// ErrAmbiguousTarget 表示名称匹配到多个 worktree
+	ErrAmbiguousTarget = i18n.Error("匹配到多个 worktree")
+	// ErrMainWorktree 表示操作不能用于主工作区
+	ErrMainWorktree = i18n.Error("不能对主工作区执行此操作")
+	// ErrBranchCheckedOut 表示分支已在其他 worktree 中检出
+	ErrBranchCheckedOut = i18n.Error("分支已在其他 worktree 中检出")
+	// ErrBranchExists 表示要创建的分支已存在
+	ErrBranchExists = i18n.Error("分支已存在")
+	// ErrInvalidReference 表示分支或提交不存在
+	ErrInvalidReference = i18n.Error("无效的引用")
+	// ErrPathExists 表示 worktree 的目标目录已存在
+	ErrPathExists = i18n.Error("目录已存在")
+	// ErrWorktreeDirty 表示 worktree 中有修改或未跟踪的文件
+	ErrWorktreeDirty = i18n.Error("worktree 有修改或未跟踪的文件")
+	// ErrLocked 表示 worktree 已被锁定
+	ErrLocked = i18n.Error("worktree 已锁定")
+	// ErrNotLocked 表示 worktree 没有被锁定
+	ErrNotLocked = i18n.Error("worktree 未锁定")
+)
+
+// BranchCheckedOutError 表示分支已在 Path 处的 worktree 中检出
+type BranchCheckedOutError struct {
+	Branch string
+	Path   string
+	Err    error
+}
+
+func (e *BranchCheckedOutError) Error() string {
+	return i18n.T("分支 %s 已在 %s 检出", e.Branch, e.Path)
+}
+
+// Unwrap 返回 ErrBranchCheckedOut 以及底层的命令错误
+func (e *BranchCheckedOutError) Unwrap() []error {
+	return []error{ErrBranchCheckedOut, e.Err}
+}
+
+// LockedError 表示 worktree 已被锁定
+type LockedError struct {
+	Path   string
+	Reason string
+	Err    error
+}
+
+func (e *LockedError) Error() string {
+	if e.Reason == "" {
+		return i18n.T("worktree 已锁定: %s", e.Path)
+	}
+	return i18n.T("worktree 已锁定: %s（%s）", e.Path, e.Reason)
+}
+
+// Unwrap 返回 ErrLocked 以及底层的命令错误
+func (e *LockedError) Unwrap() []error {
+	return []error{ErrLocked, e.Err}
+}
+
+// AmbiguousTargetError 表示名称匹配到多个 worktree
+type AmbiguousTargetError struct {
+	Target  string
+	Matches []string
+}
+
+func (e *AmbiguousTargetError) Error() string {
+	return i18n.T("%s 匹配到多个 worktree: %s", e.Target, strings.Join(e.Matches, ", "))
+}
+
+// Is 使 errors.Is(err, ErrAmbiguousTarget) 成立
+func (e *AmbiguousTargetError) Is(target error) bool {
+	return target == ErrAmbiguousTarget
+}
+
+// kindError 将 git 命令错误归类到某个哨兵错误
+type kindError struct {
+	kind error
+	err  *CommandError
+}
+
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+
+func (e *kindError) Unwrap() []error {
+	return []error{e.kind, e.err}
+}
+
+var (
+	checkedOutPattern = regexp.MustCompile(`'([^']+)' is already (?:checked out|used by worktree) at '([^']+)'`)
+	deleteBranchInUse = regexp.MustCompile(`[Cc]annot delete branch '([^']+)' (?:checked out|used by worktree) at '([^']+)'`)
+	lockReasonPattern = regexp.MustCompile(`locked(?: working tree)?, (?:lock )?reason: (.*)`)
+)
+
+// classifyError 根据 git 的错误输出把命令错误转换为可判断的类型
+//
+// 无法识别的错误原样返回。
+func classifyError(err *CommandError) error {
+	stderr := err.Stderr
+
+	if m := checkedOutPattern.FindStringSubmatch(stderr); m != nil {
+		return &BranchCheckedOutError{Branch: m[1], Path: m[2], Err: err}
+	}
+	if m := deleteBranchInUse.FindStringSubmatch(stderr); m != nil {
+		return &BranchCheckedOutError{Branch: m[1], Path: m[2], Err: err}
+	}
+
+	switch {
+	case strings.Contains(stderr, "not a git repository"):
+		return &kindError{ErrNotRepository, err}
+	case strings.Contains(stderr, "cannot remove a locked working tree"),
+		strings.Contains(stderr, "cannot move a locked working tree"),
+		strings.Contains(stderr, "is already locked"):
+		locked := &LockedError{Path: commandPath(err.Args), Err: err}
+		if m := lockReasonPattern.FindStringSubmatch(stderr); m != nil {
+			locked.Reason = strings.TrimSpace(strings.SplitN(m[1], "\n", 2)[0])
+		}
+		return locked
+	case strings.Contains(stderr, "is not locked"):
+		return &kindError{ErrNotLocked, err}
+	case strings.Contains(stderr, "contains modified or untracked files"):
+		return &kindError{ErrWorktreeDirty, err}
+	case strings.Contains(stderr, "a branch named") && strings.Contains(stderr, "already exists"):
+		return &kindError{ErrBranchExists, err}
+	case strings.Contains(stderr, "already exists"):
+		return &kindError{ErrPathExists, err}
+	case strings.Contains(stderr, "invalid reference"),
+		strings.Contains(stderr, "not a valid object name"),
+		strings.Contains(stderr, "unknown revision"):
+		return &kindError{ErrInvalidReference, err}
+	case strings.Contains(stderr, "is not a working tree"):
+		return &kindError{ErrNotFound, err}
+	case strings.Contains(stderr, "main working tree cannot be"),
+		strings.Contains(stderr, "is a main working tree"):
+		return &kindError{ErrMainWorktree, err}
+	}
+
+	return err
+}
+
+// commandPath 返回 git worktree 子命令的路径参数（最后一个参数）
+func commandPath(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	return args[len(args)-1]
+}
@@ -0,0 +1,183 @@
+package git
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestClassifyError(t *testing.T) {
+	// stderr 取自 git 2.39 和 2.42 以后的实际输出
+	tests := []struct {
+		name   string
+		args   []string
+		stderr string
+		want   error
+		check  func(t *testing.T, err error)
+	}{
+		{
+			name:   "branch checked out",
+			args:   []string{"worktree", "add", "/repo.worktrees/main", "main"},
+			stderr: "Preparing worktree (checking out 'main')\nfatal: 'main' is already checked out at '/repo'\n",
+			want:   ErrBranchCheckedOut,
+			check:  checkCheckedOut("main", "/repo"),
+		},
+		{
+			name:   "branch used by worktree",
+			args:   []string{"worktree", "add", "/repo.worktrees/main", "main"},
+			stderr: "Preparing worktree (checking out 'main')\nfatal: 'main' is already used by worktree at '/repo'\n",
+			want:   ErrBranchCheckedOut,
+			check:  checkCheckedOut("main", "/repo"),
+		},
+		{
+			name:   "delete checked out branch",
+			args:   []string{"branch", "-d", "feat"},
+			stderr: "error: Cannot delete branch 'feat' checked out at '/repo.worktrees/feat'\n",
+			want:   ErrBranchCheckedOut,
+			check:  checkCheckedOut("feat", "/repo.worktrees/feat"),
+		},
+		{
+			name:   "delete branch used by worktree",
+			args:   []string{"branch", "-D", "feat"},
+			stderr: "error: cannot delete branch 'feat' used by worktree at '/repo.worktrees/feat'\n",
+			want:   ErrBranchCheckedOut,
+			check:  checkCheckedOut("feat", "/repo.worktrees/feat"),
+		},
+		{
+			name:   "not a repository",
+			args:   []string{"rev-parse", "--git-dir"},
+			stderr: "fatal: not a git repository (or any of the parent directories): .git\n",
+			want:   ErrNotRepository,
+		},
+		{
+			name:   "remove locked with reason",
+			args:   []string{"worktree", "remove", "/repo.worktrees/feat"},
+			stderr: "fatal: cannot remove a locked working tree, lock reason: usb drive\nuse 'remove -f -f' to override or unlock first\n",
+			want:   ErrLocked,
+			check:  checkLocked("/repo.worktrees/feat", "usb drive"),
+		},
+		{
+			name:   "remove locked without reason",
+			args:   []string{"worktree", "remove", "/repo.worktrees/feat"},
+			stderr: "fatal: cannot remove a locked working tree;\nuse 'remove -f -f' to override or unlock first\n",
+			want:   ErrLocked,
+			check:  checkLocked("/repo.worktrees/feat", ""),
+		},
+		{
+			name:   "move locked",
+			args:   []string{"worktree", "move", "/repo.worktrees/feat", "/tmp/feat"},
+			stderr: "fatal: cannot move a locked working tree, lock reason: usb drive\nuse 'move -f -f' to override or unlock first\n",
+			want:   ErrLocked,
+			check:  checkLocked("/tmp/feat", "usb drive"),
+		},
+		{
+			name:   "already locked",
+			args:   []string{"worktree", "lock", "/repo.worktrees/feat"},
+			stderr: "fatal: '/repo.worktrees/feat' is already locked, reason: usb drive\n",
+			want:   ErrLocked,
+			check:  checkLocked("/repo.worktrees/feat", "usb drive"),
+		},
+		{
+			name:   "not locked",
+			args:   []string{"worktree", "unlock", "/repo.worktrees/feat"},
+			stderr: "fatal: '/repo.worktrees/feat' is not locked\n",
+			want:   ErrNotLocked,
+		},
+		{
+			name:   "dirty",
+			args:   []string{"worktree", "remove", "/repo.worktrees/feat"},
+			stderr: "fatal: '/repo.worktrees/feat' contains modified or untracked files, use --force to delete it\n",
+			want:   ErrWorktreeDirty,
+		},
+		{
+			name:   "branch exists",
+			args:   []string{"worktree", "add", "-b", "feat", "/repo.worktrees/feat"},
+			stderr: "Preparing worktree (new branch 'feat')\nfatal: a branch named 'feat' already exists\n",
+			want:   ErrBranchExists,
+		},
+		{
+			name:   "path exists",
+			args:   []string{"worktree", "add", "/repo.worktrees/feat", "feat"},
+			stderr: "Preparing worktree (checking out 'feat')\nfatal: '/repo.worktrees/feat' already exists\n",
+			want:   ErrPathExists,
+		},
+		{
+			name:   "invalid reference",
+			args:   []string{"worktree", "add", "/repo.worktrees/nope", "nope"},
+			stderr: "fatal: invalid reference: nope\n",
+			want:   ErrInvalidReference,
+		},
+		{
+			name:   "invalid base",
+			args:   []string{"worktree", "add", "-b", "feat", "/repo.worktrees/feat", "nope"},
+			stderr: "Preparing worktree (new branch 'feat')\nfatal: not a valid object name: 'nope'\n",
+			want:   ErrInvalidReference,
+		},
+		{
+			name:   "unknown revision",
+			args:   []string{"log", "nope"},
+			stderr: "fatal: ambiguous argument 'nope': unknown revision or path not in the working tree.\nUse '--' to separate paths from revisions, like this:\n'git <command> [<revision>...] -- [<file>...]'\n",
+			want:   ErrInvalidReference,
+		},
+		{
+			name:   "not a working tree",
+			args:   []string{"worktree", "remove", "/repo.worktrees/nope"},
+			stderr: "fatal: '/repo.worktrees/nope' is not a working tree\n",
+			want:   ErrNotFound,
+		},
+		{
+			name:   "lock main worktree",
+			args:   []string{"worktree", "lock", "/repo"},
+			stderr: "fatal: The main working tree cannot be locked or unlocked\n",
+			want:   ErrMainWorktree,
+		},
+		{
+			name:   "remove main worktree",
+			args:   []string{"worktree", "remove", "/repo"},
+			stderr: "fatal: '/repo' is a main working tree\n",
+			want:   ErrMainWorktree,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmdErr := &CommandError{Args: tt.args, Stderr: tt.stderr, ExitCode: 128, Err: errors.New("exit status 128")}
+			err := classifyError(cmdErr)
+
+			if !errors.Is(err, tt.want) {
+				t.Errorf("classifyError() = %v, want %v", err, tt.want)
+			}
+			var got *CommandError
+			if !errors.As(err, &got) || got != cmdErr {
+				t.Errorf("classifyError() does not wrap the command error")
+			}
+			if tt.check != nil {
+				tt.check(t, err)
+			}
+		})
+	}
+}
+
+func TestClassifyErrorUnknown(t *testing.T) {
+	cmdErr := &CommandError{Args: []string{"fetch"}, Stderr: "fatal: unable to access 'https://example.com/': Could not resolve host\n", ExitCode: 128}
+	if err := classifyError(cmdErr); err != error(cmdErr) {
+		t.Errorf("classifyError() = %#v, want the command error unchanged", err)
+	}
+}
+
+func checkCheckedOut(branch, path string) func(t *testing.T, err error) {
+	return func(t *testing.T, err error) {
+		var checkedOut *BranchCheckedOutError
+		if !errors.As(err, &checkedOut) || checkedOut.Branch != branch || checkedOut.Path != path {
+			t.Errorf("classifyError() = %#v, want branch %s checked out at %s", err, branch, path)
+		}
+	}
+}
+
+func checkLocked(path, reason string) func(t *testing.T, err error) {
+	return func(t *testing.T, err error) {
+		var locked *LockedError
+		if !errors.As(err, &locked) || locked.Path != path || locked.Reason != reason {
+			t.Errorf("classifyError() = %#v, want %s locked with reason %q", err, path, reason)
+		}
+	}
+}
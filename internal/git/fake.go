@@ -0,0 +1,122 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+// FakeRunner 按预设的输出响应 git 命令并记录所有调用，用于测试
+//
+// 响应按参数匹配：Responses 的键是以空格连接的参数（不含 git），
+// 也可以只写前缀，例如 "worktree list"。没有匹配的响应时
+// 调用 Fallback，Fallback 为空则返回错误。
+type FakeRunner struct {
+	Responses map[string]Result
+	Fallback  Runner
+
+	mu    sync.Mutex
+	calls []Command
+}
+
+// NewFakeRunner 创建 FakeRunner
+func NewFakeRunner() *FakeRunner {
+	return &FakeRunner{Responses: make(map[string]Result)}
+}
+
+// On 为参数前缀设置标准输出，退出码为 0
+func (f *FakeRunner) On(args string, stdout string) *FakeRunner {
+	f.Responses[args] = Result{Stdout: []byte(stdout)}
+	return f
+}
+
+// Fail 为参数前缀设置失败结果
+func (f *FakeRunner) Fail(args string, exitCode int, stderr string) *FakeRunner {
+	f.Responses[args] = Result{Stderr: []byte(stderr), ExitCode: exitCode}
+	return f
+}
+
+// Run 记录调用并返回最长前缀匹配的预设结果
+func (f *FakeRunner) Run(ctx context.Context, c Command) (*Result, error) {
+	f.mu.Lock()
+	f.calls = append(f.calls, c)
+	f.mu.Unlock()
+
+	key := strings.Join(c.Args, " ")
+	best := ""
+	found := false
+	for prefix := range f.Responses {
+		if (key == prefix || strings.HasPrefix(key, prefix+" ")) && len(prefix) >= len(best) {
+			best, found = prefix, true
+		}
+	}
+
+	if !found {
+		if f.Fallback != nil {
+			return f.Fallback.Run(ctx, c)
+		}
+		return nil, &CommandError{Args: c.Args, ExitCode: -1, Err: i18n.Errorf("没有预设的响应")}
+	}
+
+	response := f.Responses[best]
+	result := &response
+	if c.Stdout != nil {
+		c.Stdout.Write(result.Stdout)
+	}
+	if c.Stderr != nil {
+		c.Stderr.Write(result.Stderr)
+	}
+
+	if result.ExitCode != 0 {
+		return result, &CommandError{
+			Args:     c.Args,
+			Stderr:   string(result.Stderr),
+			ExitCode: result.ExitCode,
+			Err:      fmt.Errorf("exit status %d", result.ExitCode),
+		}
+	}
+	return result, nil
+}
+
+// Calls 返回已记录的调用
+func (f *FakeRunner) Calls() []Command {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Command(nil), f.calls...)
+}
+
+// RecordingRunner 将命令交给 Runner 执行，并记录每次调用及其结果
+type RecordingRunner struct {
+	Runner Runner
+
+	mu      sync.Mutex
+	records []Record
+}
+
+// Record 是一次被记录的 git 调用
+type Record struct {
+	Command Command
+	Result  *Result
+	Err     error
+}
+
+// Run 执行并记录 git 命令
+func (r *RecordingRunner) Run(ctx context.Context, c Command) (*Result, error) {
+	result, err := r.Runner.Run(ctx, c)
+
+	r.mu.Lock()
+	r.records = append(r.records, Record{Command: c, Result: result, Err: err})
+	r.mu.Unlock()
+
+	return result, err
+}
+
+// Records 返回已记录的调用
+func (r *RecordingRunner) Records() []Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Record(nil), r.records...)
+}
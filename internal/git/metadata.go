@@ -0,0 +1,83 @@
+package git
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+// metadataFile 是 worktree 管理目录中保存元数据的文件
+const metadataFile = "gwt-meta.json"
+
+// Metadata 是 gwt 为 worktree 记录的附加信息
+//
+// 保存在 worktree 的管理目录（公共 git 目录下的 worktrees/<name>）中，
+// 移动 worktree 后仍然保留，删除 worktree 时随之删除。
+type Metadata struct {
+	// Description 说明 worktree 的用途
+	Description string `json:"description,omitempty"`
+	// Tags 是用于筛选的标签
+	Tags []string `json:"tags,omitempty"`
+	// Ticket 是关联的工单，如 ISSUE-123 或工单链接
+	Ticket string `json:"ticket,omitempty"`
+	// Owner 是负责这个 worktree 的人
+	Owner string `json:"owner,omitempty"`
+	// CreatedAt 是 gwt 创建 worktree 的时间
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// IsEmpty 判断是否没有记录说明、标签、工单和负责人，不考虑创建时间
+func (m Metadata) IsEmpty() bool {
+	return m.Description == "" && len(m.Tags) == 0 && m.Ticket == "" && m.Owner == ""
+}
+
+// HasTag 判断是否带有标签 tag
+func (m Metadata) HasTag(tag string) bool {
+	return slices.Contains(m.Tags, tag)
+}
+
+// ReadMetadata 读取 worktree 的元数据，没有记录时返回零值
+func (r *Repository) ReadMetadata(path string) (Metadata, error) {
+	var meta Metadata
+
+	dir, err := r.AdminDir(path)
+	if err != nil {
+		return meta, i18n.Errorf("读取 worktree 元数据失败: %w", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, metadataFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return meta, nil
+	}
+	if err != nil {
+		return meta, i18n.Errorf("读取 worktree 元数据失败: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return meta, i18n.Errorf("解析 worktree 元数据失败: %w", err)
+	}
+	return meta, nil
+}
+
+// WriteMetadata 保存 worktree 的元数据
+func (r *Repository) WriteMetadata(path string, meta Metadata) error {
+	dir, err := r.AdminDir(path)
+	if err != nil {
+		return i18n.Errorf("保存 worktree 元数据失败: %w", err)
+	}
+
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return i18n.Errorf("保存 worktree 元数据失败: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, metadataFile), append(data, '\n'), 0644); err != nil {
+		return i18n.Errorf("保存 worktree 元数据失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package git
+
+import (
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+// seedCommitMessage 是用 Seed 目录创建的初始提交的说明
+const seedCommitMessage = "Initial commit"
+
+// createOrphanWorktree 在新的孤儿分支上创建 worktree
+//
+// git 2.42 起直接使用 git worktree add --orphan；更低的版本先以分离 HEAD
+// 不检出文件创建 worktree，再把 HEAD 指向尚未创建的分支，效果相同：
+// 工作目录和索引为空，第一次提交时才创建分支。
+func (r *Repository) createOrphanWorktree(options CreateWorktreeOptions) (*Worktree, error) {
+	exists, err := r.BranchExists(options.Branch)
+	if err != nil {
+		return nil, i18n.Errorf("检查分支失败: %w", err)
+	}
+	if exists {
+		return nil, i18n.Errorf("%w: %s", ErrBranchExists, options.Branch)
+	}
+
+	native := false
+	if version, err := r.Version(); err == nil {
+		native = version.AtLeast(OrphanVersion)
+	}
+
+	args := []string{"worktree", "add"}
+	if options.Force {
+		args = append(args, "--force")
+	}
+	if native {
+		args = append(args, "--orphan", "-b", options.Branch, options.Path)
+	} else {
+		args = append(args, "--detach", "--no-checkout", options.Path, "HEAD")
+	}
+
+	if _, err := r.run(r.Path, args...); err != nil {
+		return nil, i18n.Errorf("创建 worktree 失败: %w", err)
+	}
+
+	if !native {
+		if _, err := r.run(options.Path, "symbolic-ref", "HEAD", "refs/heads/"+options.Branch); err != nil {
+			r.run(r.Path, "worktree", "remove", "--force", options.Path)
+			return nil, i18n.Errorf("切换到孤儿分支失败: %w", err)
+		}
+	}
+
+	if options.Seed != "" {
+		if err := r.seedOrphan(options.Path, options.Seed); err != nil {
+			// 撤销创建的 worktree，提交已创建时一并删除分支
+			r.run(r.Path, "worktree", "remove", "--force", options.Path)
+			r.run(r.Path, "branch", "-D", options.Branch)
+			return nil, err
+		}
+	}
+
+	return &Worktree{
+		Path:   options.Path,
+		Branch: options.Branch,
+	}, nil
+}
+
+// seedOrphan 把 seed 目录中的文件作为孤儿分支的初始提交，再检出到 worktree
+//
+// 以 seed 为工作目录执行 git add，因此 seed 中的 .gitignore 同样生效，seed 本身的 .git 会被跳过。
+func (r *Repository) seedOrphan(path, seed string) error {
+	if _, err := r.run(path, "--work-tree="+seed, "add", "--all"); err != nil {
+		return i18n.Errorf("添加初始文件失败: %w", err)
+	}
+	if _, err := r.run(path, "commit", "--no-verify", "--allow-empty", "-m", seedCommitMessage); err != nil {
+		return i18n.Errorf("创建初始提交失败: %w", err)
+	}
+	if _, err := r.run(path, "reset", "--hard", "--quiet"); err != nil {
+		return i18n.Errorf("检出文件失败: %w", err)
+	}
+	return nil
+}
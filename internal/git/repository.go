@@ -1,19 +1,35 @@
 package git
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/tinsfox/gwt/internal/i18n"
 )
 
 // Repository 表示 Git 仓库
 type Repository struct {
-	Path     string
-	gitDir   string
-	worktree string
+	// Path 是执行仓库级 git 命令的目录：当前 worktree，
+	// 不在 worktree 中时为主工作区，裸仓库则为公共 git 目录
+	Path string
+	// GitDir 是当前 worktree 的 git 目录（链接 worktree 中为 .git/worktrees/<name>）
+	GitDir string
+	// CommonDir 是所有 worktree 共享的 git 目录
+	CommonDir string
+	// Worktree 是当前所在 worktree 的根目录，在 git 目录或裸仓库中时为空
+	Worktree string
+	// MainWorktree 是主工作区的根目录，裸仓库时为空
+	MainWorktree string
+	// IsBare 表示仓库是否为裸仓库
+	IsBare bool
+
+	runner Runner
+	ctx    context.Context
 }
 
 // WorktreeInfo 表示 worktree 信息
@@ -22,9 +38,24 @@ type WorktreeInfo struct {
 	Branch     string
 	IsMain     bool
 	IsLocked   bool
-	IsDirty    bool
-	CreatedAt  time.Time
-	LastCommit CommitInfo
+	LockReason string
+	// Prunable 非空时表示 worktree 目录已不存在，值为 git 给出的原因
+	Prunable string
+	IsDirty  bool
+	// CreatedAt 是 worktree 的创建时间：gwt 创建时记录，其他 worktree 根据管理目录估计
+	CreatedAt time.Time
+	// LastAccessed 是在 worktree 中最近一次 git 操作（暂存、提交、切换等）的时间
+	LastAccessed time.Time
+	LastCommit   CommitInfo
+	// Sparse 表示启用了稀疏检出，SparseDirs 是检出的目录
+	Sparse     bool
+	SparseDirs []string
+	// Describe 是分离 HEAD 时当前提交的描述（最近的标签或短哈希）
+	Describe string
+	// IsScratch 表示是 gwt scratch 创建的临时 worktree
+	IsScratch bool
+	// Metadata 是 gwt 为 worktree 记录的说明、标签等信息
+	Metadata Metadata
 }
 
 // CommitInfo 表示提交信息
@@ -41,7 +72,15 @@ type CreateWorktreeOptions struct {
 	Path         string
 	BaseBranch   string
 	CreateBranch bool
+	Detach       bool // 以分离 HEAD 方式检出 BaseBranch
 	Force        bool
+	// Sparse 非空时以 cone 模式稀疏检出这些目录：先不检出文件创建 worktree，
+	// 设置好稀疏检出后再检出
+	Sparse []string
+	// Orphan 在没有任何提交的新分支 Branch 上创建 worktree，忽略 BaseBranch 和 CreateBranch
+	Orphan bool
+	// Seed 非空时把该目录中的文件作为孤儿分支的初始提交，只在 Orphan 时使用
+	Seed string
 }
 
 // Worktree 表示创建的 worktree
@@ -52,73 +91,193 @@ type Worktree struct {
 
 // OpenRepository 打开 Git 仓库
 func OpenRepository(path string) (*Repository, error) {
+	return Open(context.Background(), path, nil)
+}
+
+// Open 使用指定的 Runner 打开 Git 仓库，runner 为 nil 时使用 DefaultRunner
+//
+// 通过 git rev-parse 发现仓库，因此在链接 worktree、裸仓库、子模块
+// 以及设置了 GIT_DIR 的环境中都能得到正确的路径。
+func Open(ctx context.Context, path string, runner Runner) (*Repository, error) {
 	// 检查路径是否存在
 	if _, err := os.Stat(path); err != nil {
-		return nil, fmt.Errorf("路径不存在: %s", path)
+		return nil, i18n.Errorf("%w: 路径不存在: %s", ErrNotRepository, path)
 	}
 
-	// 查找 .git 目录
-	gitDir, err := findGitDir(path)
+	absPath, err := filepath.Abs(path)
 	if err != nil {
-		return nil, fmt.Errorf("不是 Git 仓库: %s", path)
+		return nil, i18n.Errorf("转换路径失败: %w", err)
 	}
 
-	// 获取仓库根目录
-	repoPath := filepath.Dir(gitDir)
-	if filepath.Base(gitDir) == ".git" {
-		repoPath = filepath.Dir(gitDir)
+	if runner == nil {
+		runner = DefaultRunner
 	}
+	repo := &Repository{runner: runner, ctx: ctx}
 
-	return &Repository{
-		Path:   repoPath,
-		gitDir: gitDir,
-	}, nil
-}
+	output, err := repo.run(absPath, "rev-parse", "--git-dir", "--git-common-dir", "--is-bare-repository", "--is-inside-work-tree")
+	if err != nil {
+		if errors.Is(err, ErrNotRepository) {
+			return nil, fmt.Errorf("%w: %s", ErrNotRepository, absPath)
+		}
+		return nil, i18n.Errorf("打开仓库失败: %w", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	if len(lines) < 4 {
+		return nil, i18n.Errorf("解析 git rev-parse 输出失败: %s", string(output))
+	}
+
+	repo.GitDir = resolvePath(absPath, lines[0])
+	repo.CommonDir = resolvePath(absPath, lines[1])
+	repo.IsBare = lines[2] == "true"
 
-// findGitDir 查找 .git 目录
-func findGitDir(path string) (string, error) {
-	// 尝试直接查找 .git 目录
-	gitPath := filepath.Join(path, ".git")
-	if info, err := os.Stat(gitPath); err == nil {
-		if info.IsDir() {
-			return gitPath, nil
+	// --show-toplevel 在 worktree 之外会失败，只在 worktree 中查询
+	if lines[3] == "true" {
+		toplevel, err := repo.output(absPath, "rev-parse", "--show-toplevel")
+		if err != nil {
+			return nil, i18n.Errorf("获取 worktree 根目录失败: %w", err)
 		}
-		// 可能是 gitfile
-		content, err := os.ReadFile(gitPath)
-		if err == nil {
-			line := strings.TrimSpace(string(content))
-			if strings.HasPrefix(line, "gitdir: ") {
-				gitdir := strings.TrimPrefix(line, "gitdir: ")
-				if filepath.IsAbs(gitdir) {
-					return gitdir, nil
-				}
-				return filepath.Join(path, gitdir), nil
-			}
+		repo.Worktree = resolvePath(absPath, toplevel)
+	}
+
+	// 主工作区是 git worktree list 的第一项，裸仓库没有主工作区
+	if main, bare, err := repo.firstWorktree(); err == nil {
+		if bare {
+			repo.IsBare = true
+		} else {
+			repo.MainWorktree = main
+		}
+	}
+
+	// 子模块的 git 目录位于父仓库的 .git/modules 下，git worktree list
+	// 会把它当作主工作区，实际的工作区由 core.worktree 指定；
+	// 通过 GIT_DIR 和 GIT_WORK_TREE 指定时没有 core.worktree，当前 worktree 就是主工作区
+	if repo.MainWorktree == repo.CommonDir {
+		if worktree, err := repo.output(repo.CommonDir, "config", "--get", "core.worktree"); err == nil {
+			repo.MainWorktree = resolvePath(repo.CommonDir, worktree)
+		} else if repo.Worktree != "" && repo.GitDir == repo.CommonDir {
+			repo.MainWorktree = repo.Worktree
 		}
 	}
 
-	// 向上查找
-	parent := filepath.Dir(path)
-	if parent == path {
-		return "", fmt.Errorf("未找到 .git 目录")
+	switch {
+	case repo.Worktree != "":
+		repo.Path = repo.Worktree
+	case repo.MainWorktree != "":
+		repo.Path = repo.MainWorktree
+	default:
+		repo.Path = repo.CommonDir
 	}
 
-	return findGitDir(parent)
+	return repo, nil
 }
 
-// GetWorktrees 获取所有 worktree
-func (r *Repository) GetWorktrees() ([]WorktreeInfo, error) {
-	cmd := exec.Command("git", "worktree", "list", "--porcelain")
-	cmd.Dir = r.Path
+// WithContext 返回使用指定 context 执行 git 命令的仓库副本
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	copy := *r
+	copy.ctx = ctx
+	return &copy
+}
+
+// firstWorktree 获取 git worktree list 的第一项及其是否为裸仓库
+func (r *Repository) firstWorktree() (string, bool, error) {
+	output, err := r.run(r.CommonDir, "worktree", "list", "--porcelain")
+	if err != nil {
+		return "", false, err
+	}
 
-	output, err := cmd.Output()
+	var path string
+	for _, line := range strings.Split(string(output), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+		if strings.HasPrefix(line, "worktree ") {
+			path = strings.TrimPrefix(line, "worktree ")
+		} else if line == "bare" {
+			return path, true, nil
+		}
+	}
+
+	return path, false, nil
+}
+
+// resolvePath 将 git 输出的相对路径转换为绝对路径
+func resolvePath(base, path string) string {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(base, path)
+	}
+	return filepath.Clean(path)
+}
+
+// ListWorktrees 获取 git worktree list 的全部条目，包括可清理的 worktree，不补充状态和提交信息
+func (r *Repository) ListWorktrees() ([]WorktreeInfo, error) {
+	output, err := r.run(r.Path, "worktree", "list", "--porcelain")
 	if err != nil {
-		return nil, fmt.Errorf("执行 git worktree list 失败: %w", err)
+		return nil, i18n.Errorf("执行 git worktree list 失败: %w", err)
 	}
 
 	return parseWorktreeList(string(output))
 }
 
+// GetWorktrees 获取所有 worktree，不包括目录已不存在的 worktree
+func (r *Repository) GetWorktrees() ([]WorktreeInfo, error) {
+	all, err := r.ListWorktrees()
+	if err != nil {
+		return nil, err
+	}
+
+	worktrees := all[:0]
+	for _, wt := range all {
+		if wt.Prunable == "" {
+			worktrees = append(worktrees, wt)
+		}
+	}
+
+	// 补充信息
+	for i := range worktrees {
+		wt := &worktrees[i]
+
+		// 检查是否有修改
+		wt.IsDirty = r.isWorktreeDirty(wt.Path)
+
+		// 获取最后提交信息
+		commit, err := r.lastCommit(wt.Path)
+		if err == nil {
+			wt.LastCommit = commit
+		}
+
+		// 稀疏检出的目录
+		if dirs, err := r.SparseDirs(wt.Path); err == nil {
+			wt.Sparse = true
+			wt.SparseDirs = dirs
+		}
+
+		if wt.Branch == "" {
+			wt.Describe = r.Describe(wt.Path)
+		}
+		wt.IsScratch = r.IsScratch(wt.Path)
+
+		if meta, err := r.ReadMetadata(wt.Path); err == nil {
+			wt.Metadata = meta
+			wt.CreatedAt = meta.CreatedAt
+		}
+		if dir, err := r.AdminDir(wt.Path); err == nil {
+			if wt.CreatedAt.IsZero() {
+				wt.CreatedAt = adminCreatedAt(dir)
+			}
+			wt.LastAccessed = adminAccessedAt(dir)
+		}
+	}
+
+	// 修正子模块主工作区的路径，见 OpenRepository
+	if len(worktrees) > 0 && worktrees[0].IsMain && worktrees[0].Path == r.CommonDir && r.MainWorktree != "" {
+		worktrees[0].Path = r.MainWorktree
+	}
+
+	return worktrees, nil
+}
+
 // parseWorktreeList 解析 worktree 列表输出
 func parseWorktreeList(output string) ([]WorktreeInfo, error) {
 	var worktrees []WorktreeInfo
@@ -147,12 +306,18 @@ func parseWorktreeList(output string) ([]WorktreeInfo, error) {
 			current.LastCommit.Hash = head
 		} else if strings.HasPrefix(line, "branch ") {
 			branch := strings.TrimPrefix(line, "branch ")
-			current.Branch = filepath.Base(branch)
-		} else if strings.HasPrefix(line, "locked ") {
+			current.Branch = strings.TrimPrefix(branch, "refs/heads/")
+		} else if line == "locked" || strings.HasPrefix(line, "locked ") {
 			current.IsLocked = true
-		} else if strings.HasPrefix(line, "prunable ") {
-			// 忽略可清理的 worktree
-			current = nil
+			current.LockReason = strings.TrimSpace(strings.TrimPrefix(line, "locked"))
+		} else if line == "bare" {
+			// 裸仓库本身不是 worktree
+			current.Path = ""
+		} else if line == "prunable" || strings.HasPrefix(line, "prunable ") {
+			current.Prunable = strings.TrimSpace(strings.TrimPrefix(line, "prunable"))
+			if current.Prunable == "" {
+				current.Prunable = "gitdir file points to non-existent location"
+			}
 		}
 	}
 
@@ -160,62 +325,64 @@ func parseWorktreeList(output string) ([]WorktreeInfo, error) {
 		worktrees = append(worktrees, *current)
 	}
 
-	// 补充信息
-	for i := range worktrees {
-		wt := &worktrees[i]
-
-		// 检查是否是主工作区
-		wt.IsMain = isMainWorktree(wt.Path)
-
-		// 检查是否有修改
-		wt.IsDirty = isWorktreeDirty(wt.Path)
+	// git worktree list 的第一项是主工作区（裸仓库时第一项是仓库本身，
+	// 已被标记为空路径）
+	if len(worktrees) > 0 && worktrees[0].Path != "" {
+		worktrees[0].IsMain = true
+	}
 
-		// 获取最后提交信息
-		commit, err := getLastCommit(wt.Path)
-		if err == nil {
-			wt.LastCommit = commit
+	valid := worktrees[:0]
+	for _, wt := range worktrees {
+		if wt.Path != "" {
+			valid = append(valid, wt)
 		}
 	}
-
-	return worktrees, nil
+	return valid, nil
 }
 
-// isMainWorktree 检查是否是主工作区
-func isMainWorktree(path string) bool {
-	gitPath := filepath.Join(path, ".git")
-	info, err := os.Stat(gitPath)
+// isWorktreeDirty 检查 worktree 是否有修改
+//
+// 使用 --no-optional-locks，避免 git status 顺便刷新索引而改变 worktree 的最近访问时间。
+func (r *Repository) isWorktreeDirty(path string) bool {
+	output, err := r.run(path, "--no-optional-locks", "status", "--porcelain")
 	if err != nil {
 		return false
 	}
-	return info.IsDir()
+
+	return len(strings.TrimSpace(string(output))) > 0
 }
 
-// isWorktreeDirty 检查 worktree 是否有修改
-func isWorktreeDirty(path string) bool {
-	cmd := exec.Command("git", "status", "--porcelain")
-	cmd.Dir = path
+// TrackedFiles 返回 files 中受版本控制的文件，路径相对于 dir 中 worktree 的根目录
+func (r *Repository) TrackedFiles(dir string, files []string) (map[string]bool, error) {
+	tracked := make(map[string]bool)
+	if len(files) == 0 {
+		return tracked, nil
+	}
 
-	output, err := cmd.Output()
+	args := append([]string{"ls-files", "-z", "--full-name", "--"}, files...)
+	output, err := r.run(dir, args...)
 	if err != nil {
-		return false
+		return nil, i18n.Errorf("获取受版本控制的文件失败: %w", err)
 	}
 
-	return len(strings.TrimSpace(string(output))) > 0
+	for _, file := range strings.Split(string(output), "\x00") {
+		if file != "" {
+			tracked[filepath.FromSlash(file)] = true
+		}
+	}
+	return tracked, nil
 }
 
-// getLastCommit 获取最后提交信息
-func getLastCommit(path string) (CommitInfo, error) {
-	cmd := exec.Command("git", "log", "-1", "--pretty=format:%H|%s|%an|%ai", "HEAD")
-	cmd.Dir = path
-
-	output, err := cmd.Output()
+// lastCommit 获取最后提交信息
+func (r *Repository) lastCommit(path string) (CommitInfo, error) {
+	output, err := r.run(path, "log", "-1", "--pretty=format:%H|%s|%an|%ai", "HEAD")
 	if err != nil {
 		return CommitInfo{}, err
 	}
 
 	parts := strings.Split(string(output), "|")
 	if len(parts) < 4 {
-		return CommitInfo{}, fmt.Errorf("解析提交信息失败")
+		return CommitInfo{}, i18n.Errorf("解析提交信息失败")
 	}
 
 	commitTime, err := time.Parse("2006-01-02 15:04:05 -0700", parts[3])
@@ -233,10 +400,7 @@ func getLastCommit(path string) (CommitInfo, error) {
 
 // BranchExists 检查分支是否存在
 func (r *Repository) BranchExists(branch string) (bool, error) {
-	cmd := exec.Command("git", "branch", "--list", branch)
-	cmd.Dir = r.Path
-
-	output, err := cmd.Output()
+	output, err := r.run(r.Path, "branch", "--list", branch)
 	if err != nil {
 		return false, err
 	}
@@ -244,30 +408,60 @@ func (r *Repository) BranchExists(branch string) (bool, error) {
 	return len(strings.TrimSpace(string(output))) > 0, nil
 }
 
+// CheckBranchName 使用 git check-ref-format 检查分支名是否合法
+func (r *Repository) CheckBranchName(branch string) error {
+	if _, err := r.run(r.Path, "check-ref-format", "--branch", branch); err != nil {
+		return i18n.Errorf("%w: 分支名不合法: %s", ErrInvalidReference, branch)
+	}
+	return nil
+}
+
 // CreateWorktree 创建 worktree
 func (r *Repository) CreateWorktree(options CreateWorktreeOptions) (*Worktree, error) {
+	if options.Orphan {
+		return r.createOrphanWorktree(options)
+	}
+
 	args := []string{"worktree", "add"}
 
 	if options.Force {
 		args = append(args, "--force")
 	}
+	if len(options.Sparse) > 0 {
+		args = append(args, "--no-checkout")
+	}
 
 	if options.CreateBranch {
 		args = append(args, "-b", options.Branch)
+	} else if options.Detach {
+		args = append(args, "--detach")
 	}
 
-	if options.BaseBranch != "" {
-		args = append(args, options.BaseBranch)
-	}
-
+	// git worktree add [-b <branch>] <path> [<commit-ish>]
 	args = append(args, options.Path)
 
-	cmd := exec.Command("git", args...)
-	cmd.Dir = r.Path
+	if options.CreateBranch || options.Detach {
+		if options.BaseBranch != "" {
+			args = append(args, options.BaseBranch)
+		}
+	} else {
+		args = append(args, options.Branch)
+	}
 
-	output, err := cmd.CombinedOutput()
+	_, err := r.run(r.Path, args...)
 	if err != nil {
-		return nil, fmt.Errorf("创建 worktree 失败: %w\n输出: %s", err, string(output))
+		return nil, i18n.Errorf("创建 worktree 失败: %w", err)
+	}
+
+	if len(options.Sparse) > 0 {
+		if err := r.checkoutSparse(options.Path, options.Sparse); err != nil {
+			// 撤销创建的 worktree 和分支，避免留下没有检出文件的 worktree
+			r.run(r.Path, "worktree", "remove", "--force", options.Path)
+			if options.CreateBranch {
+				r.run(r.Path, "branch", "-D", options.Branch)
+			}
+			return nil, err
+		}
 	}
 
 	return &Worktree{
@@ -276,14 +470,71 @@ func (r *Repository) CreateWorktree(options CreateWorktreeOptions) (*Worktree, e
 	}, nil
 }
 
-// RemoveWorktree 删除 worktree
-func (r *Repository) RemoveWorktree(path string) error {
-	cmd := exec.Command("git", "worktree", "remove", path)
-	cmd.Dir = r.Path
+// checkoutSparse 为以 --no-checkout 创建的 worktree 设置稀疏检出并检出文件
+func (r *Repository) checkoutSparse(path string, dirs []string) error {
+	if err := r.SetSparse(path, dirs); err != nil {
+		return err
+	}
+	if _, err := r.run(path, "checkout"); err != nil {
+		return i18n.Errorf("检出文件失败: %w", err)
+	}
+	return nil
+}
+
+// RemoveWorktree 删除 worktree，force 为 true 时即使有未提交的修改也删除
+func (r *Repository) RemoveWorktree(path string, force bool) error {
+	args := []string{"worktree", "remove"}
+	if force {
+		args = append(args, "--force")
+	}
+	args = append(args, path)
+
+	_, err := r.run(r.Path, args...)
+	if err != nil {
+		return i18n.Errorf("删除 worktree 失败: %w", err)
+	}
+
+	return nil
+}
+
+// PrunableWorktrees 获取目录已不存在、可以清理的 worktree
+func (r *Repository) PrunableWorktrees() ([]WorktreeInfo, error) {
+	all, err := r.ListWorktrees()
+	if err != nil {
+		return nil, err
+	}
+
+	var prunable []WorktreeInfo
+	for _, wt := range all {
+		if wt.Prunable != "" {
+			prunable = append(prunable, wt)
+		}
+	}
+
+	return prunable, nil
+}
 
-	output, err := cmd.CombinedOutput()
+// LockWorktree 锁定 worktree，防止被移动、删除或清理
+func (r *Repository) LockWorktree(path, reason string) error {
+	args := []string{"worktree", "lock"}
+	if reason != "" {
+		args = append(args, "--reason", reason)
+	}
+	args = append(args, path)
+
+	_, err := r.run(r.Path, args...)
 	if err != nil {
-		return fmt.Errorf("删除 worktree 失败: %w\n输出: %s", err, string(output))
+		return i18n.Errorf("锁定 worktree 失败: %w", err)
+	}
+
+	return nil
+}
+
+// UnlockWorktree 解锁 worktree
+func (r *Repository) UnlockWorktree(path string) error {
+	_, err := r.run(r.Path, "worktree", "unlock", path)
+	if err != nil {
+		return i18n.Errorf("解锁 worktree 失败: %w", err)
 	}
 
 	return nil
@@ -291,12 +542,113 @@ func (r *Repository) RemoveWorktree(path string) error {
 
 // PruneWorktrees 清理无效的 worktree
 func (r *Repository) PruneWorktrees() error {
-	cmd := exec.Command("git", "worktree", "prune")
-	cmd.Dir = r.Path
+	_, err := r.run(r.Path, "worktree", "prune")
+	if err != nil {
+		return i18n.Errorf("清理 worktree 失败: %w", err)
+	}
+
+	return nil
+}
+
+// MergedBranches 获取已合并到指定分支的本地分支
+func (r *Repository) MergedBranches(base string) ([]string, error) {
+	output, err := r.run(r.Path, "branch", "--format=%(refname:short)", "--merged", base)
+	if err != nil {
+		return nil, i18n.Errorf("获取已合并分支失败: %w", err)
+	}
+
+	var branches []string
+	for _, line := range strings.Split(string(output), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			branches = append(branches, line)
+		}
+	}
+
+	return branches, nil
+}
+
+// DefaultBranch 获取默认分支（优先使用 origin/HEAD，其次是 main/master）
+func (r *Repository) DefaultBranch() (string, error) {
+	if ref, err := r.output("", "symbolic-ref", "--short", "refs/remotes/origin/HEAD"); err == nil {
+		return strings.TrimPrefix(ref, "origin/"), nil
+	}
+
+	for _, branch := range []string{"main", "master"} {
+		if exists, err := r.BranchExists(branch); err == nil && exists {
+			return branch, nil
+		}
+	}
+
+	return "", i18n.Errorf("无法确定默认分支")
+}
+
+// BranchMergedInto 检查分支是否已合并到基准分支或其上游分支
+//
+// 返回分支已合并到的目标：已合并到基准分支时为 base，只合并到上游时为上游的远程引用
+// refs/remotes/<remote>/<branch>，都没有合并时为空字符串。优先检查基准分支。
+func (r *Repository) BranchMergedInto(branch, base string) (string, error) {
+	tip, err := r.RevParse(r.Path, "refs/heads/"+branch)
+	if err != nil {
+		return "", err
+	}
+
+	targets := []string{}
+	if base != "" {
+		targets = append(targets, base)
+	}
+	if remote, remoteBranch := r.BranchUpstream(branch); remote != "" {
+		targets = append(targets, "refs/remotes/"+remote+"/"+remoteBranch)
+	}
+
+	for _, target := range targets {
+		if _, err := r.RevParse(r.Path, target); err != nil {
+			continue
+		}
+		if r.IsAncestor(r.Path, tip, target) {
+			return target, nil
+		}
+	}
+
+	return "", nil
+}
+
+// BranchUpstream 获取分支的上游远程名和远程分支名，没有上游时返回空字符串
+func (r *Repository) BranchUpstream(branch string) (string, string) {
+	output, err := r.run(r.Path, "for-each-ref", "--format=%(upstream:remotename) %(upstream:remoteref)", "refs/heads/"+branch)
+	if err != nil {
+		return "", ""
+	}
+
+	parts := strings.Fields(string(output))
+	// 上游为本地分支时远程名是 "."
+	if len(parts) != 2 || parts[0] == "." {
+		return "", ""
+	}
+
+	return parts[0], strings.TrimPrefix(parts[1], "refs/heads/")
+}
+
+// DeleteBranch 删除本地分支，force 为 true 时即使未合并也删除
+func (r *Repository) DeleteBranch(branch string, force bool) error {
+	flag := "-d"
+	if force {
+		flag = "-D"
+	}
+
+	_, err := r.run(r.Path, "branch", flag, branch)
+	if err != nil {
+		return i18n.Errorf("删除分支失败: %w", err)
+	}
+
+	return nil
+}
 
-	output, err := cmd.CombinedOutput()
+// DeleteRemoteBranch 删除远程分支
+func (r *Repository) DeleteRemoteBranch(remote, branch string) error {
+	_, err := r.run(r.Path, "push", remote, "--delete", branch)
 	if err != nil {
-		return fmt.Errorf("清理 worktree 失败: %w\n输出: %s", err, string(output))
+		return i18n.Errorf("删除远程分支失败: %w", err)
 	}
 
 	return nil
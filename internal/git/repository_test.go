@@ -0,0 +1,462 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// gitEnv 让测试中的 git 不读取用户和系统配置，结果不受运行环境影响
+var gitEnv = []string{
+	"GIT_CONFIG_GLOBAL=" + os.DevNull,
+	"GIT_CONFIG_NOSYSTEM=1",
+	"GIT_AUTHOR_NAME=gwt",
+	"GIT_AUTHOR_EMAIL=gwt@example.com",
+	"GIT_COMMITTER_NAME=gwt",
+	"GIT_COMMITTER_EMAIL=gwt@example.com",
+	"LC_ALL=C",
+}
+
+// tempDir 返回解析过符号链接的临时目录，与 git 输出的路径一致
+func tempDir(t *testing.T) string {
+	t.Helper()
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// gitRun 在 dir 中执行 git 命令，失败时结束测试
+func gitRun(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), gitEnv...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, output)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// initRepo 在 dir 中创建带有一个提交的仓库，默认分支为 main
+func initRepo(t *testing.T, dir string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, dir, "init", "--quiet", "--initial-branch=main")
+	gitRun(t, dir, "commit", "--quiet", "--allow-empty", "-m", "init")
+}
+
+// setGitEnv 让被测代码执行的 git 同样不读取用户和系统配置
+func setGitEnv(t *testing.T) {
+	t.Helper()
+	for _, kv := range gitEnv {
+		key, value, _ := strings.Cut(kv, "=")
+		t.Setenv(key, value)
+	}
+}
+
+func TestOpenRepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	setGitEnv(t)
+
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, root string) (open string, env map[string]string)
+		want  func(root string) Repository
+	}{
+		{
+			name: "normal",
+			setup: func(t *testing.T, root string) (string, map[string]string) {
+				initRepo(t, filepath.Join(root, "repo"))
+				return filepath.Join(root, "repo"), nil
+			},
+			want: func(root string) Repository {
+				repo := filepath.Join(root, "repo")
+				return Repository{
+					Path:         repo,
+					GitDir:       filepath.Join(repo, ".git"),
+					CommonDir:    filepath.Join(repo, ".git"),
+					Worktree:     repo,
+					MainWorktree: repo,
+				}
+			},
+		},
+		{
+			name: "subdirectory",
+			setup: func(t *testing.T, root string) (string, map[string]string) {
+				repo := filepath.Join(root, "repo")
+				initRepo(t, repo)
+				if err := os.MkdirAll(filepath.Join(repo, "src", "pkg"), 0755); err != nil {
+					t.Fatal(err)
+				}
+				return filepath.Join(repo, "src", "pkg"), nil
+			},
+			want: func(root string) Repository {
+				repo := filepath.Join(root, "repo")
+				return Repository{
+					Path:         repo,
+					GitDir:       filepath.Join(repo, ".git"),
+					CommonDir:    filepath.Join(repo, ".git"),
+					Worktree:     repo,
+					MainWorktree: repo,
+				}
+			},
+		},
+		{
+			name: "linked worktree",
+			setup: func(t *testing.T, root string) (string, map[string]string) {
+				initRepo(t, filepath.Join(root, "repo"))
+				gitRun(t, filepath.Join(root, "repo"), "worktree", "add", "--quiet", "-b", "feature", filepath.Join(root, "feature"))
+				return filepath.Join(root, "feature"), nil
+			},
+			want: func(root string) Repository {
+				repo := filepath.Join(root, "repo")
+				return Repository{
+					Path:         filepath.Join(root, "feature"),
+					GitDir:       filepath.Join(repo, ".git", "worktrees", "feature"),
+					CommonDir:    filepath.Join(repo, ".git"),
+					Worktree:     filepath.Join(root, "feature"),
+					MainWorktree: repo,
+				}
+			},
+		},
+		{
+			name: "main worktree git dir",
+			setup: func(t *testing.T, root string) (string, map[string]string) {
+				initRepo(t, filepath.Join(root, "repo"))
+				gitRun(t, filepath.Join(root, "repo"), "worktree", "add", "--quiet", "-b", "feature", filepath.Join(root, "feature"))
+				return filepath.Join(root, "repo", ".git"), nil
+			},
+			want: func(root string) Repository {
+				repo := filepath.Join(root, "repo")
+				return Repository{
+					Path:         repo,
+					GitDir:       filepath.Join(repo, ".git"),
+					CommonDir:    filepath.Join(repo, ".git"),
+					MainWorktree: repo,
+				}
+			},
+		},
+		{
+			name: "bare layout",
+			setup: func(t *testing.T, root string) (string, map[string]string) {
+				setupBareLayout(t, root)
+				return filepath.Join(root, "project"), nil
+			},
+			want: func(root string) Repository {
+				bare := filepath.Join(root, "project", ".bare")
+				return Repository{
+					Path:      bare,
+					GitDir:    bare,
+					CommonDir: bare,
+					IsBare:    true,
+				}
+			},
+		},
+		{
+			name: "bare layout worktree",
+			setup: func(t *testing.T, root string) (string, map[string]string) {
+				setupBareLayout(t, root)
+				return filepath.Join(root, "project", "main"), nil
+			},
+			want: func(root string) Repository {
+				bare := filepath.Join(root, "project", ".bare")
+				return Repository{
+					Path:      filepath.Join(root, "project", "main"),
+					GitDir:    filepath.Join(bare, "worktrees", "main"),
+					CommonDir: bare,
+					Worktree:  filepath.Join(root, "project", "main"),
+					IsBare:    true,
+				}
+			},
+		},
+		{
+			name: "GIT_DIR and GIT_WORK_TREE",
+			setup: func(t *testing.T, root string) (string, map[string]string) {
+				initRepo(t, filepath.Join(root, "repo"))
+				if err := os.Rename(filepath.Join(root, "repo", ".git"), filepath.Join(root, "store.git")); err != nil {
+					t.Fatal(err)
+				}
+				return filepath.Join(root, "repo"), map[string]string{
+					"GIT_DIR":       filepath.Join(root, "store.git"),
+					"GIT_WORK_TREE": filepath.Join(root, "repo"),
+				}
+			},
+			want: func(root string) Repository {
+				return Repository{
+					Path:         filepath.Join(root, "repo"),
+					GitDir:       filepath.Join(root, "store.git"),
+					CommonDir:    filepath.Join(root, "store.git"),
+					Worktree:     filepath.Join(root, "repo"),
+					MainWorktree: filepath.Join(root, "repo"),
+				}
+			},
+		},
+		{
+			name: "submodule",
+			setup: func(t *testing.T, root string) (string, map[string]string) {
+				initRepo(t, filepath.Join(root, "lib"))
+				initRepo(t, filepath.Join(root, "super"))
+				gitRun(t, filepath.Join(root, "super"), "-c", "protocol.file.allow=always",
+					"submodule", "--quiet", "add", filepath.Join(root, "lib"), "lib")
+				return filepath.Join(root, "super", "lib"), nil
+			},
+			want: func(root string) Repository {
+				modules := filepath.Join(root, "super", ".git", "modules", "lib")
+				return Repository{
+					Path:         filepath.Join(root, "super", "lib"),
+					GitDir:       modules,
+					CommonDir:    modules,
+					Worktree:     filepath.Join(root, "super", "lib"),
+					MainWorktree: filepath.Join(root, "super", "lib"),
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := tempDir(t)
+			path, env := tt.setup(t, root)
+			for key, value := range env {
+				t.Setenv(key, value)
+			}
+
+			repo, err := OpenRepository(path)
+			if err != nil {
+				t.Fatalf("OpenRepository(%s): %v", path, err)
+			}
+
+			want := tt.want(root)
+			got := Repository{
+				Path:         repo.Path,
+				GitDir:       repo.GitDir,
+				CommonDir:    repo.CommonDir,
+				Worktree:     repo.Worktree,
+				MainWorktree: repo.MainWorktree,
+				IsBare:       repo.IsBare,
+			}
+			if got != want {
+				t.Errorf("OpenRepository(%s)\n got: %+v\nwant: %+v", path, got, want)
+			}
+		})
+	}
+}
+
+// setupBareLayout 创建 project/.bare 裸仓库布局：project/.git 指向 .bare，
+// main 分支检出在 project/main
+func setupBareLayout(t *testing.T, root string) {
+	t.Helper()
+	initRepo(t, filepath.Join(root, "origin"))
+
+	project := filepath.Join(root, "project")
+	gitRun(t, root, "clone", "--quiet", "--bare", filepath.Join(root, "origin"), filepath.Join(project, ".bare"))
+	if err := os.WriteFile(filepath.Join(project, ".git"), []byte("gitdir: ./.bare\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, project, "worktree", "add", "--quiet", filepath.Join(project, "main"), "main")
+}
+
+func TestOpenRepositoryNotRepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	setGitEnv(t)
+	t.Setenv("GIT_CEILING_DIRECTORIES", os.TempDir())
+
+	dir := tempDir(t)
+	if _, err := OpenRepository(dir); !errors.Is(err, ErrNotRepository) {
+		t.Errorf("OpenRepository(%s) = %v, want ErrNotRepository", dir, err)
+	}
+	if _, err := OpenRepository(filepath.Join(dir, "missing")); !errors.Is(err, ErrNotRepository) {
+		t.Errorf("OpenRepository(missing) = %v, want ErrNotRepository", err)
+	}
+}
+
+func TestGetWorktrees(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	setGitEnv(t)
+
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, root string) string
+		want  func(root string) []worktreeSummary
+	}{
+		{
+			name: "linked worktrees",
+			setup: func(t *testing.T, root string) string {
+				repo := filepath.Join(root, "repo")
+				initRepo(t, repo)
+				gitRun(t, repo, "worktree", "add", "--quiet", "-b", "feature", filepath.Join(root, "feature"))
+				gitRun(t, repo, "worktree", "add", "--quiet", "--detach", filepath.Join(root, "detached"))
+				gitRun(t, repo, "worktree", "lock", "--reason", "usb drive", filepath.Join(root, "feature"))
+				return filepath.Join(root, "feature")
+			},
+			want: func(root string) []worktreeSummary {
+				return []worktreeSummary{
+					{Path: filepath.Join(root, "repo"), Branch: "main", IsMain: true},
+					{Path: filepath.Join(root, "detached")},
+					{Path: filepath.Join(root, "feature"), Branch: "feature", IsLocked: true, LockReason: "usb drive"},
+				}
+			},
+		},
+		{
+			name: "bare layout",
+			setup: func(t *testing.T, root string) string {
+				setupBareLayout(t, root)
+				return filepath.Join(root, "project")
+			},
+			want: func(root string) []worktreeSummary {
+				return []worktreeSummary{
+					{Path: filepath.Join(root, "project", "main"), Branch: "main"},
+				}
+			},
+		},
+		{
+			name: "submodule",
+			setup: func(t *testing.T, root string) string {
+				initRepo(t, filepath.Join(root, "lib"))
+				initRepo(t, filepath.Join(root, "super"))
+				gitRun(t, filepath.Join(root, "super"), "-c", "protocol.file.allow=always",
+					"submodule", "--quiet", "add", filepath.Join(root, "lib"), "lib")
+				return filepath.Join(root, "super", "lib")
+			},
+			want: func(root string) []worktreeSummary {
+				return []worktreeSummary{
+					{Path: filepath.Join(root, "super", "lib"), Branch: "main", IsMain: true},
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := tempDir(t)
+			repo, err := OpenRepository(tt.setup(t, root))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			worktrees, err := repo.GetWorktrees()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// 链接 worktree 的顺序取决于文件系统读取管理目录的顺序
+			slices.SortStableFunc(worktrees[min(1, len(worktrees)):], func(a, b WorktreeInfo) int {
+				return strings.Compare(a.Path, b.Path)
+			})
+
+			want := tt.want(root)
+			if len(worktrees) != len(want) {
+				t.Fatalf("GetWorktrees() returned %d worktrees, want %d: %+v", len(worktrees), len(want), worktrees)
+			}
+			for i, wt := range worktrees {
+				if got := summarize(wt); got != want[i] {
+					t.Errorf("worktree %d\n got: %+v\nwant: %+v", i, got, want[i])
+				}
+			}
+		})
+	}
+}
+
+// worktreeSummary 是 WorktreeInfo 中由 git worktree list 得到的字段，可以直接比较
+type worktreeSummary struct {
+	Path       string
+	Branch     string
+	IsMain     bool
+	IsLocked   bool
+	LockReason string
+	Prunable   string
+}
+
+func summarize(wt WorktreeInfo) worktreeSummary {
+	return worktreeSummary{
+		Path:       wt.Path,
+		Branch:     wt.Branch,
+		IsMain:     wt.IsMain,
+		IsLocked:   wt.IsLocked,
+		LockReason: wt.LockReason,
+		Prunable:   wt.Prunable,
+	}
+}
+
+func TestParseWorktreeList(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   []worktreeSummary
+	}{
+		{
+			name: "main worktree",
+			output: `worktree /repo
+HEAD 1111111111111111111111111111111111111111
+branch refs/heads/main
+
+worktree /repo.worktrees/feature
+HEAD 2222222222222222222222222222222222222222
+branch refs/heads/feature/login
+locked reason with spaces
+`,
+			want: []worktreeSummary{
+				{Path: "/repo", Branch: "main", IsMain: true},
+				{Path: "/repo.worktrees/feature", Branch: "feature/login", IsLocked: true, LockReason: "reason with spaces"},
+			},
+		},
+		{
+			name: "bare",
+			output: `worktree /repo/.bare
+bare
+
+worktree /repo/main
+HEAD 1111111111111111111111111111111111111111
+branch refs/heads/main
+
+worktree /repo/detached
+HEAD 3333333333333333333333333333333333333333
+detached
+locked
+
+worktree /repo/gone
+HEAD 4444444444444444444444444444444444444444
+detached
+prunable gitdir file points to non-existent location
+`,
+			want: []worktreeSummary{
+				{Path: "/repo/main", Branch: "main"},
+				{Path: "/repo/detached", IsLocked: true},
+				{Path: "/repo/gone", Prunable: "gitdir file points to non-existent location"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			worktrees, err := parseWorktreeList(tt.output)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(worktrees) != len(tt.want) {
+				t.Fatalf("parseWorktreeList() returned %d worktrees, want %d: %+v", len(worktrees), len(tt.want), worktrees)
+			}
+			for i, wt := range worktrees {
+				if got := summarize(wt); got != tt.want[i] {
+					t.Errorf("worktree %d\n got: %+v\nwant: %+v", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+// Command 描述一次 git 调用
+type Command struct {
+	// Dir 是执行命令的目录
+	Dir string
+	// Args 是传给 git 的参数，不包括 git 本身
+	Args []string
+	// Env 是追加到当前进程环境变量之后的 KEY=VALUE
+	Env []string
+	// Stdin 是命令的标准输入，为 nil 时不提供输入
+	Stdin io.Reader
+	// Stdout、Stderr 非空时，输出在被捕获的同时实时写入
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// String 返回命令的可读形式
+func (c Command) String() string {
+	return "git " + strings.Join(c.Args, " ")
+}
+
+// Result 表示 git 命令的执行结果
+type Result struct {
+	Stdout   []byte
+	Stderr   []byte
+	ExitCode int
+	Duration time.Duration
+}
+
+// Runner 执行 git 命令
+type Runner interface {
+	// Run 执行 git 命令，退出码非 0 时同时返回结果和 *CommandError
+	Run(ctx context.Context, cmd Command) (*Result, error)
+}
+
+// CommandError 表示 git 命令执行失败
+type CommandError struct {
+	Args     []string
+	Stderr   string
+	ExitCode int
+	Err      error
+}
+
+// Error 返回包含 git 错误输出的错误信息
+func (e *CommandError) Error() string {
+	msg := fmt.Sprintf("git %s: %v", strings.Join(e.Args, " "), e.Err)
+	if stderr := strings.TrimSpace(e.Stderr); stderr != "" {
+		msg += "\n" + stderr
+	}
+	return msg
+}
+
+// Unwrap 返回底层错误
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// ExecRunner 通过 os/exec 执行系统中的 git
+//
+// git 总是以 C locale 执行：classifyError 根据英文的错误输出判断错误类型，
+// 本地化的输出无法识别。需要时可以通过 Env 覆盖。
+type ExecRunner struct {
+	// Path 是 git 可执行文件路径，默认在 PATH 中查找 git
+	Path string
+	// Env 是每条命令都追加的环境变量
+	Env []string
+}
+
+// localeEnv 让 git 输出英文信息
+var localeEnv = []string{"LC_ALL=C", "LANGUAGE="}
+
+// Run 执行 git 命令
+func (r ExecRunner) Run(ctx context.Context, c Command) (*Result, error) {
+	path := r.Path
+	if path == "" {
+		path = "git"
+	}
+
+	cmd := exec.CommandContext(ctx, path, c.Args...)
+	cmd.Dir = c.Dir
+	cmd.Env = append(append(append(os.Environ(), localeEnv...), r.Env...), c.Env...)
+	cmd.Stdin = c.Stdin
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = teeWriter(&stdout, c.Stdout)
+	cmd.Stderr = teeWriter(&stderr, c.Stderr)
+
+	start := time.Now()
+	err := cmd.Run()
+
+	result := &Result{
+		Stdout:   stdout.Bytes(),
+		Stderr:   stderr.Bytes(),
+		ExitCode: cmd.ProcessState.ExitCode(),
+		Duration: time.Since(start),
+	}
+
+	if err != nil {
+		return result, &CommandError{Args: c.Args, Stderr: stderr.String(), ExitCode: result.ExitCode, Err: err}
+	}
+
+	return result, nil
+}
+
+// teeWriter 在捕获输出的同时写入 w（w 为 nil 时只捕获）
+func teeWriter(buf *bytes.Buffer, w io.Writer) io.Writer {
+	if w == nil {
+		return buf
+	}
+	return io.MultiWriter(buf, w)
+}
+
+// TraceRunner 在执行每条 git 命令后输出命令、目录、退出码和耗时
+type TraceRunner struct {
+	Runner Runner
+	Out    io.Writer
+}
+
+// Run 执行并记录 git 命令
+func (t TraceRunner) Run(ctx context.Context, c Command) (*Result, error) {
+	result, err := t.Runner.Run(ctx, c)
+
+	exit, duration := -1, time.Duration(0)
+	if result != nil {
+		exit, duration = result.ExitCode, result.Duration
+	}
+	fmt.Fprintf(t.Out, "[git] %s (in %s) exit=%d %s\n", c, c.Dir, exit, duration.Round(time.Millisecond))
+
+	return result, err
+}
+
+// DefaultRunner 是未指定 Runner 时使用的实现
+var DefaultRunner Runner = ExecRunner{}
+
+// run 在 dir 中执行 git 命令并返回标准输出，dir 为空时使用仓库目录
+func (r *Repository) run(dir string, args ...string) ([]byte, error) {
+	return r.runCommand(Command{Dir: dir, Args: args})
+}
+
+// runCommand 执行完整描述的 git 命令并返回标准输出，Dir 为空时使用仓库目录
+func (r *Repository) runCommand(c Command) ([]byte, error) {
+	if c.Dir == "" {
+		c.Dir = r.Path
+	}
+
+	result, err := r.runner.Run(r.ctx, c)
+	if result == nil {
+		if err == nil {
+			err = i18n.Error("git runner 没有返回结果")
+		}
+		return nil, err
+	}
+
+	var cmdErr *CommandError
+	if errors.As(err, &cmdErr) {
+		err = classifyError(cmdErr)
+	}
+	return result.Stdout, err
+}
+
+// output 执行 git 命令并返回去除首尾空白的输出
+func (r *Repository) output(dir string, args ...string) (string, error) {
+	output, err := r.run(dir, args...)
+	return strings.TrimSpace(string(output)), err
+}
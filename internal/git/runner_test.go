@@ -0,0 +1,263 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestExecRunner(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	setGitEnv(t)
+
+	repo := tempDir(t)
+	initRepo(t, repo)
+	sub := filepath.Join(repo, "sub")
+	if err := os.Mkdir(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// 通过 GIT_CONFIG_* 传入配置，检查环境变量确实传给了 git
+	runner := &RecordingRunner{Runner: ExecRunner{Env: []string{
+		"GIT_CONFIG_COUNT=1",
+		"GIT_CONFIG_KEY_0=gwt.value",
+		"GIT_CONFIG_VALUE_0=runner",
+	}}}
+	ctx := context.Background()
+	blob := gitRun(t, repo, "hash-object", writeFile(t, "gwt\n"))
+
+	tests := []struct {
+		name    string
+		command Command
+		want    string
+	}{
+		{
+			name:    "dir",
+			command: Command{Dir: sub, Args: []string{"rev-parse", "--show-prefix"}},
+			want:    "sub/",
+		},
+		{
+			name:    "runner env",
+			command: Command{Dir: repo, Args: []string{"config", "--get", "gwt.value"}},
+			want:    "runner",
+		},
+		{
+			name:    "command env overrides runner env",
+			command: Command{Dir: repo, Args: []string{"config", "--get", "gwt.value"}, Env: []string{"GIT_CONFIG_VALUE_0=command"}},
+			want:    "command",
+		},
+		{
+			name:    "stdin",
+			command: Command{Dir: repo, Args: []string{"hash-object", "--stdin"}, Stdin: strings.NewReader("gwt\n")},
+			want:    blob,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := runner.Run(ctx, tt.command)
+			if err != nil {
+				t.Fatalf("Run(%s): %v", tt.command, err)
+			}
+			if got := strings.TrimSpace(string(result.Stdout)); got != tt.want {
+				t.Errorf("Run(%s) = %q, want %q", tt.command, got, tt.want)
+			}
+			if result.ExitCode != 0 {
+				t.Errorf("Run(%s) exit code = %d, want 0", tt.command, result.ExitCode)
+			}
+		})
+	}
+
+	t.Run("failure", func(t *testing.T) {
+		var stderr bytes.Buffer
+		result, err := runner.Run(ctx, Command{Dir: repo, Args: []string{"rev-parse", "--verify", "missing"}, Stderr: &stderr})
+
+		var cmdErr *CommandError
+		if !errors.As(err, &cmdErr) {
+			t.Fatalf("Run() error = %v, want *CommandError", err)
+		}
+		if result == nil || result.ExitCode == 0 || cmdErr.ExitCode != result.ExitCode {
+			t.Errorf("Run() result = %+v, error exit code = %d", result, cmdErr.ExitCode)
+		}
+		if cmdErr.Stderr == "" || cmdErr.Stderr != stderr.String() {
+			t.Errorf("Run() stderr = %q, tee = %q", cmdErr.Stderr, stderr.String())
+		}
+	})
+
+	records := runner.Records()
+	if len(records) != len(tests)+1 {
+		t.Fatalf("RecordingRunner recorded %d commands, want %d", len(records), len(tests)+1)
+	}
+	if last := records[len(records)-1]; last.Err == nil || !slices.Equal(last.Command.Args, []string{"rev-parse", "--verify", "missing"}) {
+		t.Errorf("last record = %+v", last)
+	}
+}
+
+func TestExecRunnerLocale(t *testing.T) {
+	env, err := exec.LookPath("env")
+	if err != nil {
+		t.Skip("env not found")
+	}
+	t.Setenv("LC_ALL", "de_DE.UTF-8")
+	t.Setenv("LANGUAGE", "de")
+
+	// 用 env 代替 git，输出 git 会得到的环境变量
+	run := func(runner ExecRunner, c Command) map[string]string {
+		t.Helper()
+		runner.Path = env
+		result, err := runner.Run(context.Background(), c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		vars := make(map[string]string)
+		for _, line := range strings.Split(string(result.Stdout), "\n") {
+			if key, value, ok := strings.Cut(line, "="); ok {
+				vars[key] = value
+			}
+		}
+		return vars
+	}
+
+	vars := run(ExecRunner{}, Command{})
+	if vars["LC_ALL"] != "C" || vars["LANGUAGE"] != "" {
+		t.Errorf("git runs with LC_ALL=%q LANGUAGE=%q, want C locale", vars["LC_ALL"], vars["LANGUAGE"])
+	}
+
+	vars = run(ExecRunner{Env: []string{"LC_ALL=C.UTF-8"}}, Command{Env: []string{"GWT_TEST=1"}})
+	if vars["LC_ALL"] != "C.UTF-8" || vars["GWT_TEST"] != "1" {
+		t.Errorf("Env does not override locale: LC_ALL=%q GWT_TEST=%q", vars["LC_ALL"], vars["GWT_TEST"])
+	}
+}
+
+// writeFile 把 content 写入临时文件并返回路径
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestTraceRunner(t *testing.T) {
+	fake := NewFakeRunner().
+		On("status --porcelain", " M file\n").
+		Fail("rev-parse --verify", 128, "fatal: Needed a single revision\n")
+
+	var out bytes.Buffer
+	runner := TraceRunner{Runner: fake, Out: &out}
+	ctx := context.Background()
+
+	if _, err := runner.Run(ctx, Command{Dir: "/repo", Args: []string{"status", "--porcelain"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runner.Run(ctx, Command{Dir: "/repo/sub", Args: []string{"rev-parse", "--verify", "missing"}}); err == nil {
+		t.Fatal("Run(rev-parse --verify missing) succeeded, want error")
+	}
+	if _, err := runner.Run(ctx, Command{Dir: "/repo", Args: []string{"fetch"}}); err == nil {
+		t.Fatal("Run(fetch) succeeded without a preset response")
+	}
+
+	want := []string{
+		"[git] git status --porcelain (in /repo) exit=0 ",
+		"[git] git rev-parse --verify missing (in /repo/sub) exit=128 ",
+		"[git] git fetch (in /repo) exit=-1 ",
+	}
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("trace output:\n%s\nwant %d lines", out.String(), len(want))
+	}
+	for i, line := range lines {
+		if !strings.HasPrefix(line, want[i]) {
+			t.Errorf("trace line %d = %q, want prefix %q", i, line, want[i])
+		}
+	}
+
+	if calls := fake.Calls(); len(calls) != 3 || calls[1].Dir != "/repo/sub" {
+		t.Errorf("FakeRunner recorded %+v", calls)
+	}
+}
+
+// openFake 用 FakeRunner 打开位于 /repo 的普通仓库
+func openFake(t *testing.T, fake *FakeRunner) *Repository {
+	t.Helper()
+	fake.On("rev-parse --git-dir", "/repo/.git\n/repo/.git\nfalse\ntrue\n").
+		On("rev-parse --show-toplevel", "/repo\n").
+		On("worktree list --porcelain", "worktree /repo\nHEAD 1111111111111111111111111111111111111111\nbranch refs/heads/main\n\n")
+
+	repo, err := Open(context.Background(), t.TempDir(), fake)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return repo
+}
+
+func TestOpenWithFakeRunner(t *testing.T) {
+	repo := openFake(t, NewFakeRunner())
+
+	if repo.Path != "/repo" || repo.CommonDir != "/repo/.git" || repo.MainWorktree != "/repo" || repo.IsBare {
+		t.Errorf("Open() = %+v", repo)
+	}
+}
+
+func TestBranchUpstreamWithFakeRunner(t *testing.T) {
+	tests := []struct {
+		name       string
+		output     string
+		wantRemote string
+		wantBranch string
+	}{
+		{name: "remote", output: "origin refs/heads/feature/login\n", wantRemote: "origin", wantBranch: "feature/login"},
+		{name: "local upstream", output: ". refs/heads/main\n"},
+		{name: "no upstream", output: " \n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := NewFakeRunner()
+			repo := openFake(t, fake)
+			fake.On("for-each-ref", tt.output)
+
+			remote, branch := repo.BranchUpstream("feature/login")
+			if remote != tt.wantRemote || branch != tt.wantBranch {
+				t.Errorf("BranchUpstream() = %q, %q, want %q, %q", remote, branch, tt.wantRemote, tt.wantBranch)
+			}
+
+			calls := fake.Calls()
+			last := calls[len(calls)-1]
+			if last.Dir != "/repo" || last.Args[len(last.Args)-1] != "refs/heads/feature/login" {
+				t.Errorf("BranchUpstream() ran %s in %s", last, last.Dir)
+			}
+		})
+	}
+}
+
+func TestCreateWorktreeClassifiesErrors(t *testing.T) {
+	fake := NewFakeRunner()
+	repo := openFake(t, fake)
+	fake.Fail("worktree add", 128, "fatal: 'main' is already checked out at '/repo'\n")
+
+	_, err := repo.CreateWorktree(CreateWorktreeOptions{Branch: "main", Path: "/repo.worktrees/main"})
+
+	var checkedOut *BranchCheckedOutError
+	if !errors.As(err, &checkedOut) {
+		t.Fatalf("CreateWorktree() error = %v, want *BranchCheckedOutError", err)
+	}
+	if checkedOut.Branch != "main" || checkedOut.Path != "/repo" || !errors.Is(err, ErrBranchCheckedOut) {
+		t.Errorf("CreateWorktree() error = %+v", checkedOut)
+	}
+
+	calls := fake.Calls()
+	want := []string{"worktree", "add", "/repo.worktrees/main", "main"}
+	if last := calls[len(calls)-1]; !slices.Equal(last.Args, want) {
+		t.Errorf("CreateWorktree() ran %v, want %v", last.Args, want)
+	}
+}
@@ -0,0 +1,131 @@
+package git
+
+import (
+	"strings"
+
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+// WorktreeChanges 表示删除 worktree 时可能丢失的工作
+type WorktreeChanges struct {
+	Modified  []string     // 已跟踪文件的修改（包括暂存区）
+	Deleted   []string     // 已删除的已跟踪文件
+	Untracked []string     // 未跟踪的文件（不含被忽略的文件）
+	Stashes   []string     // 在该分支上创建的 stash（保存在仓库中，不会随 worktree 删除）
+	Unpushed  []CommitInfo // 不在任何远程分支或其他本地分支上的提交
+}
+
+// HasUnsavedWork 是否有删除 worktree 后会丢失的工作
+func (c *WorktreeChanges) HasUnsavedWork() bool {
+	return len(c.Modified) > 0 || len(c.Deleted) > 0 || len(c.Untracked) > 0 || len(c.Unpushed) > 0
+}
+
+// InspectWorktree 检查 worktree 中尚未保存的工作
+func (r *Repository) InspectWorktree(path, branch string) (*WorktreeChanges, error) {
+	changes := &WorktreeChanges{}
+
+	if err := r.inspectStatus(path, changes); err != nil {
+		return nil, err
+	}
+
+	stashes, err := r.branchStashes(path, branch)
+	if err != nil {
+		return nil, err
+	}
+	changes.Stashes = stashes
+
+	unpushed, err := r.unpushedCommits(path, branch)
+	if err != nil {
+		return nil, err
+	}
+	changes.Unpushed = unpushed
+
+	return changes, nil
+}
+
+// inspectStatus 解析 git status 输出
+func (r *Repository) inspectStatus(path string, changes *WorktreeChanges) error {
+	output, err := r.run(path, "status", "--porcelain", "-z", "--untracked-files=all")
+	if err != nil {
+		return i18n.Errorf("获取 worktree 状态失败: %w", err)
+	}
+
+	entries := strings.Split(string(output), "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
+			continue
+		}
+
+		x, y, file := entry[0], entry[1], entry[3:]
+		switch {
+		case x == '?' && y == '?':
+			changes.Untracked = append(changes.Untracked, file)
+		case x == 'D' || y == 'D':
+			changes.Deleted = append(changes.Deleted, file)
+		default:
+			changes.Modified = append(changes.Modified, file)
+		}
+
+		// 重命名和复制的条目后面跟着原路径
+		if x == 'R' || x == 'C' {
+			if i+1 < len(entries) {
+				changes.Deleted = append(changes.Deleted, entries[i+1])
+			}
+			i++
+		}
+	}
+
+	return nil
+}
+
+// branchStashes 获取在指定分支上创建的 stash
+func (r *Repository) branchStashes(path, branch string) ([]string, error) {
+	if branch == "" {
+		return nil, nil
+	}
+
+	output, err := r.run(path, "stash", "list", "--format=%gd: %gs")
+	if err != nil {
+		return nil, i18n.Errorf("获取 stash 列表失败: %w", err)
+	}
+
+	var stashes []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.Contains(line, "WIP on "+branch+":") || strings.Contains(line, "On "+branch+":") {
+			stashes = append(stashes, line)
+		}
+	}
+
+	return stashes, nil
+}
+
+// unpushedCommits 获取只存在于该 worktree 的提交
+func (r *Repository) unpushedCommits(path, branch string) ([]CommitInfo, error) {
+	args := []string{"log", "--pretty=format:%H|%s|%an|%ai", "HEAD", "--not"}
+	if branch != "" {
+		// 不同版本的 git 对 --branches 的排除模式是否带 refs/heads/ 前缀处理不同，两种都传
+		args = append(args, "--exclude="+branch, "--exclude=refs/heads/"+branch)
+	}
+	args = append(args, "--branches", "--remotes")
+
+	output, err := r.run(path, args...)
+	if err != nil {
+		return nil, i18n.Errorf("获取未推送的提交失败: %w", err)
+	}
+
+	var commits []CommitInfo
+	for _, line := range strings.Split(string(output), "\n") {
+		parts := strings.SplitN(line, "|", 4)
+		if len(parts) < 4 {
+			continue
+		}
+		commits = append(commits, CommitInfo{
+			Hash:    parts[0],
+			Subject: parts[1],
+			Author:  parts[2],
+		})
+	}
+
+	return commits, nil
+}
@@ -0,0 +1,70 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+// scratchMarker 是临时 worktree 管理目录中的标记文件
+const scratchMarker = "gwt-scratch"
+
+// AdminDir 返回 worktree 的管理目录
+//
+// 链接 worktree 为公共 git 目录下的 worktrees/<name>，主工作区为其 .git 目录。
+// 通过读取 worktree 中的 .git 文件得到，不执行 git 命令。
+func (r *Repository) AdminDir(path string) (string, error) {
+	dotGit := filepath.Join(path, ".git")
+	info, err := os.Stat(dotGit)
+	if err != nil {
+		return "", err
+	}
+	if info.IsDir() {
+		return dotGit, nil
+	}
+
+	data, err := os.ReadFile(dotGit)
+	if err != nil {
+		return "", err
+	}
+	gitdir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return "", i18n.Errorf("无法解析 %s", dotGit)
+	}
+	return resolvePath(path, strings.TrimSpace(gitdir)), nil
+}
+
+// MarkScratch 把 worktree 标记为临时 worktree，gwt clean 会删除这些 worktree
+//
+// 标记保存在 worktree 的管理目录中，删除 worktree 时随之删除。
+func (r *Repository) MarkScratch(path string) error {
+	dir, err := r.AdminDir(path)
+	if err != nil {
+		return i18n.Errorf("标记临时 worktree 失败: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, scratchMarker), nil, 0644); err != nil {
+		return i18n.Errorf("标记临时 worktree 失败: %w", err)
+	}
+	return nil
+}
+
+// IsScratch 判断 worktree 是否为临时 worktree
+func (r *Repository) IsScratch(path string) bool {
+	dir, err := r.AdminDir(path)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(filepath.Join(dir, scratchMarker))
+	return err == nil
+}
+
+// Describe 返回 worktree 当前提交的描述，优先使用最近的标签，如 v1.2.0-3-gabc1234
+func (r *Repository) Describe(path string) string {
+	output, err := r.output(path, "describe", "--tags", "--always", "HEAD")
+	if err != nil {
+		return ""
+	}
+	return output
+}
@@ -0,0 +1,96 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+// ErrNotSparse 表示 worktree 没有启用稀疏检出
+var ErrNotSparse = i18n.Error("worktree 没有启用稀疏检出")
+
+// SparseDirs 获取 worktree 稀疏检出（cone 模式）的目录
+//
+// 没有启用稀疏检出时返回 ErrNotSparse；启用了但只检出根目录的文件时返回空列表。
+func (r *Repository) SparseDirs(path string) ([]string, error) {
+	sparse, err := r.IsSparse(path)
+	if err != nil {
+		return nil, err
+	}
+	if !sparse {
+		return nil, ErrNotSparse
+	}
+
+	output, err := r.output(path, "sparse-checkout", "list")
+	if err != nil {
+		return nil, i18n.Errorf("获取稀疏检出目录失败: %w", err)
+	}
+
+	dirs := []string{}
+	if output != "" {
+		dirs = strings.Split(output, "\n")
+	}
+	return dirs, nil
+}
+
+// IsSparse 判断 worktree 是否启用了稀疏检出
+//
+// 管理目录中没有 info/sparse-checkout 时一定没有启用，不执行 git 命令；
+// 关闭稀疏检出后这个文件仍会保留，因此存在时再读取 core.sparseCheckout。
+func (r *Repository) IsSparse(path string) (bool, error) {
+	if dir, err := r.AdminDir(path); err == nil {
+		if _, err := os.Stat(filepath.Join(dir, "info", "sparse-checkout")); errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+	}
+
+	output, err := r.output(path, "config", "--type=bool", "--get", "core.sparseCheckout")
+	if err != nil {
+		// 没有设置时 git config 的退出码为 1
+		var cmdErr *CommandError
+		if errors.As(err, &cmdErr) && cmdErr.ExitCode == 1 {
+			return false, nil
+		}
+		return false, i18n.Errorf("读取稀疏检出配置失败: %w", err)
+	}
+	return output == "true", nil
+}
+
+// SetSparse 把 worktree 的稀疏检出设置为 dirs，没有启用时以 cone 模式启用
+func (r *Repository) SetSparse(path string, dirs []string) error {
+	if _, err := r.SparseDirs(path); errors.Is(err, ErrNotSparse) {
+		if _, err := r.run(path, "sparse-checkout", "init", "--cone"); err != nil {
+			return i18n.Errorf("启用稀疏检出失败: %w", err)
+		}
+	}
+
+	args := append([]string{"sparse-checkout", "set"}, dirs...)
+	if _, err := r.run(path, args...); err != nil {
+		return i18n.Errorf("设置稀疏检出目录失败: %w", err)
+	}
+	return nil
+}
+
+// AddSparse 在 worktree 的稀疏检出中添加目录
+func (r *Repository) AddSparse(path string, dirs []string) error {
+	if _, err := r.SparseDirs(path); err != nil {
+		return err
+	}
+
+	args := append([]string{"sparse-checkout", "add"}, dirs...)
+	if _, err := r.run(path, args...); err != nil {
+		return i18n.Errorf("添加稀疏检出目录失败: %w", err)
+	}
+	return nil
+}
+
+// DisableSparse 关闭 worktree 的稀疏检出，检出所有文件
+func (r *Repository) DisableSparse(path string) error {
+	if _, err := r.run(path, "sparse-checkout", "disable"); err != nil {
+		return i18n.Errorf("关闭稀疏检出失败: %w", err)
+	}
+	return nil
+}
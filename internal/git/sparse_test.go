@@ -0,0 +1,74 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestSparseDirs(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	setGitEnv(t)
+
+	root := tempDir(t)
+	main := filepath.Join(root, "repo")
+	initRepo(t, main)
+	for _, dir := range []string{"web", "api"} {
+		if err := os.MkdirAll(filepath.Join(main, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(main, dir, "file"), []byte(dir), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	gitRun(t, main, "add", ".")
+	gitRun(t, main, "commit", "--quiet", "-m", "dirs")
+
+	feature := filepath.Join(root, "feature")
+	gitRun(t, main, "worktree", "add", "--quiet", "-b", "feature", feature)
+
+	repo, err := OpenRepository(main)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repo.SetSparse(feature, []string{"web"}); err != nil {
+		t.Fatal(err)
+	}
+	if dirs, err := repo.SparseDirs(feature); err != nil || !slices.Equal(dirs, []string{"web"}) {
+		t.Errorf("SparseDirs(feature) = %v, %v, want [web]", dirs, err)
+	}
+	if _, err := repo.SparseDirs(main); !errors.Is(err, ErrNotSparse) {
+		t.Errorf("SparseDirs(main) error = %v, want ErrNotSparse", err)
+	}
+
+	if err := repo.DisableSparse(feature); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.SparseDirs(feature); !errors.Is(err, ErrNotSparse) {
+		t.Errorf("SparseDirs(feature) after disable error = %v, want ErrNotSparse", err)
+	}
+}
+
+func TestIsSparseWithoutGit(t *testing.T) {
+	fake := NewFakeRunner()
+	repo := openFake(t, fake)
+	calls := len(fake.Calls())
+
+	worktree := t.TempDir()
+	if err := os.Mkdir(filepath.Join(worktree, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if sparse, err := repo.IsSparse(worktree); sparse || err != nil {
+		t.Errorf("IsSparse() = %v, %v, want false", sparse, err)
+	}
+	if extra := fake.Calls()[calls:]; len(extra) != 0 {
+		t.Errorf("IsSparse() ran git without a sparse-checkout file: %v", extra)
+	}
+}
@@ -0,0 +1,167 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+// ErrNotFastForward 表示无法快进（分支已分叉）
+var ErrNotFastForward = i18n.Error("无法快进，分支已分叉")
+
+// ErrRebaseConflict 表示 rebase 过程中出现冲突
+var ErrRebaseConflict = i18n.Error("rebase 出现冲突")
+
+// Fetch 从远程获取更新，remote 为空时获取所有远程
+func (r *Repository) Fetch(remote string) error {
+	args := []string{"fetch", "--prune"}
+	if remote == "" {
+		args = append(args, "--all")
+	} else {
+		args = append(args, remote)
+	}
+
+	_, err := r.run(r.Path, args...)
+	if err != nil {
+		return i18n.Errorf("获取远程更新失败: %w", err)
+	}
+
+	return nil
+}
+
+// Remotes 获取所有远程仓库名
+func (r *Repository) Remotes() ([]string, error) {
+	output, err := r.output(r.Path, "remote")
+	if err != nil {
+		return nil, i18n.Errorf("获取远程仓库列表失败: %w", err)
+	}
+	if output == "" {
+		return nil, nil
+	}
+	return strings.Split(output, "\n"), nil
+}
+
+// RemoteOf 返回 ref 所属的远程仓库名，如 origin/main 返回 origin，不是远程分支时返回空字符串
+func (r *Repository) RemoteOf(ref string) string {
+	remotes, err := r.Remotes()
+	if err != nil {
+		return ""
+	}
+	for _, remote := range remotes {
+		if strings.HasPrefix(ref, remote+"/") {
+			return remote
+		}
+	}
+	return ""
+}
+
+// Upstream 获取 worktree 当前分支的上游分支，没有上游时返回空字符串
+func (r *Repository) Upstream(path string) (string, error) {
+	output, err := r.run(path, "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}")
+	if err != nil {
+		// 没有配置上游分支
+		return "", nil
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// AheadBehind 获取 worktree 当前分支相对上游分支领先和落后的提交数
+func (r *Repository) AheadBehind(path, upstream string) (int, int, error) {
+	output, err := r.output(path, "rev-list", "--left-right", "--count", "HEAD..."+upstream)
+	if err != nil {
+		return 0, 0, i18n.Errorf("比较上游分支失败: %w", err)
+	}
+
+	var ahead, behind int
+	if _, err := fmt.Sscanf(output, "%d\t%d", &ahead, &behind); err != nil {
+		return 0, 0, i18n.Errorf("解析提交数失败: %s", output)
+	}
+
+	return ahead, behind, nil
+}
+
+// RevParse 在 worktree 中解析引用为提交哈希
+func (r *Repository) RevParse(path, ref string) (string, error) {
+	output, err := r.run(path, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil {
+		return "", i18n.Errorf("无法解析引用: %s", ref)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// IsAncestor 检查 ancestor 是否是 descendant 的祖先
+func (r *Repository) IsAncestor(path, ancestor, descendant string) bool {
+	_, err := r.run(path, "merge-base", "--is-ancestor", ancestor, descendant)
+	return err == nil
+}
+
+// FastForward 将 worktree 当前分支快进到目标引用
+func (r *Repository) FastForward(path, target string, autostash bool) error {
+	head, err := r.RevParse(path, "HEAD")
+	if err != nil {
+		return err
+	}
+	if !r.IsAncestor(path, head, target) {
+		return ErrNotFastForward
+	}
+
+	args := []string{"merge", "--ff-only"}
+	if autostash {
+		args = append(args, "--autostash")
+	}
+	args = append(args, target)
+
+	_, err = r.run(path, args...)
+	if err != nil {
+		return i18n.Errorf("快进失败: %w", err)
+	}
+
+	return nil
+}
+
+// Rebase 将 worktree 当前分支变基到目标引用
+//
+// 出现冲突时保留 rebase 进行中的状态并返回 ErrRebaseConflict，
+// 由用户在 worktree 中继续或中止。
+func (r *Repository) Rebase(path, target string, autostash bool) error {
+	args := []string{"rebase"}
+	if autostash {
+		args = append(args, "--autostash")
+	}
+	args = append(args, target)
+
+	_, err := r.run(path, args...)
+	if err != nil {
+		if r.IsRebaseInProgress(path) {
+			return ErrRebaseConflict
+		}
+		return i18n.Errorf("rebase 失败: %w", err)
+	}
+
+	return nil
+}
+
+// IsRebaseInProgress 检查 worktree 是否有进行中的 rebase
+func (r *Repository) IsRebaseInProgress(path string) bool {
+	output, err := r.run(path, "rev-parse", "--git-dir")
+	if err != nil {
+		return false
+	}
+
+	gitDir := strings.TrimSpace(string(output))
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(path, gitDir)
+	}
+
+	for _, name := range []string{"rebase-merge", "rebase-apply"} {
+		if _, err := os.Stat(filepath.Join(gitDir, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,335 @@
+package git
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+// trashArchive 回收站中保存未提交文件的归档名
+const trashArchive = "files.tar.gz"
+
+// TrashEntry 表示回收站中的一个已删除 worktree
+type TrashEntry struct {
+	ID        string    `json:"id"`
+	Path      string    `json:"path"`
+	Branch    string    `json:"branch"`
+	Head      string    `json:"head"`
+	Ref       string    `json:"ref"`
+	Deleted   []string  `json:"deleted,omitempty"`
+	Files     int       `json:"files"`
+	RemovedAt time.Time `json:"removed_at"`
+}
+
+// TrashDir 获取回收站目录
+func (r *Repository) TrashDir() string {
+	return filepath.Join(r.CommonDir, "gwt", "trash")
+}
+
+// maxTrashAttempts 是生成不重复的回收站条目 ID 的最多尝试次数
+const maxTrashAttempts = 100
+
+// MoveToTrash 在删除 worktree 之前备份其未保存的工作
+//
+// HEAD 会记录到 refs/gwt/trash/<id>，防止未推送的提交被垃圾回收；
+// 修改过的和未跟踪的文件会打包到回收站目录中。
+func (r *Repository) MoveToTrash(wt WorktreeInfo, changes *WorktreeChanges) (*TrashEntry, error) {
+	// 分离 HEAD 的 worktree 没有分支名，使用目录名区分
+	name := wt.Branch
+	if name == "" {
+		name = filepath.Base(wt.Path)
+	}
+	base := time.Now().Format("20060102-150405") + "-" + strings.NewReplacer("/", "-", " ", "-").Replace(name)
+
+	id, entryDir, err := r.createTrashEntry(base)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &TrashEntry{
+		ID:        id,
+		Path:      wt.Path,
+		Branch:    wt.Branch,
+		Ref:       "refs/gwt/trash/" + id,
+		Deleted:   changes.Deleted,
+		RemovedAt: time.Now(),
+	}
+
+	head, err := r.RevParse(wt.Path, "HEAD")
+	if err == nil {
+		// 旧值为空表示引用必须不存在，不会覆盖其他条目的恢复引用
+		if _, err := r.run("", "update-ref", entry.Ref, head, ""); err != nil {
+			os.RemoveAll(entryDir)
+			return nil, i18n.Errorf("创建恢复引用失败: %w", err)
+		}
+		entry.Head = head
+	}
+
+	files := append(append([]string{}, changes.Modified...), changes.Untracked...)
+	if len(files) > 0 {
+		if err := writeArchive(filepath.Join(entryDir, trashArchive), wt.Path, files); err != nil {
+			r.dropTrash(entry)
+			return nil, err
+		}
+	}
+	entry.Files = len(files)
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		r.dropTrash(entry)
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(entryDir, "entry.json"), data, 0644); err != nil {
+		r.dropTrash(entry)
+		return nil, i18n.Errorf("写入回收站记录失败: %w", err)
+	}
+
+	return entry, nil
+}
+
+// createTrashEntry 创建回收站条目目录，返回条目 ID 和目录
+//
+// ID 为 base，已被占用时（例如同一秒内删除了同名的 worktree）依次加上序号。
+// 使用 os.Mkdir 创建，目录已存在时不会复用，多个进程同时删除也不会互相覆盖。
+func (r *Repository) createTrashEntry(base string) (string, string, error) {
+	if err := os.MkdirAll(r.TrashDir(), 0755); err != nil {
+		return "", "", i18n.Errorf("创建回收站目录失败: %w", err)
+	}
+
+	for attempt := 1; attempt <= maxTrashAttempts; attempt++ {
+		id := base
+		if attempt > 1 {
+			id = fmt.Sprintf("%s-%d", base, attempt)
+		}
+
+		dir := filepath.Join(r.TrashDir(), id)
+		err := os.Mkdir(dir, 0755)
+		if err == nil {
+			return id, dir, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return "", "", i18n.Errorf("创建回收站目录失败: %w", err)
+		}
+	}
+
+	return "", "", i18n.Errorf("回收站条目已存在: %s", base)
+}
+
+// TrashEntries 获取回收站中的所有条目，按删除时间倒序排列
+func (r *Repository) TrashEntries() ([]TrashEntry, error) {
+	trashDir := r.TrashDir()
+	dirs, err := os.ReadDir(trashDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, i18n.Errorf("读取回收站失败: %w", err)
+	}
+
+	var entries []TrashEntry
+	for _, dir := range dirs {
+		data, err := os.ReadFile(filepath.Join(trashDir, dir.Name(), "entry.json"))
+		if err != nil {
+			continue
+		}
+
+		var entry TrashEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].RemovedAt.After(entries[j].RemovedAt)
+	})
+
+	return entries, nil
+}
+
+// RestoreFromTrash 从回收站恢复 worktree，path 为空时恢复到原路径
+func (r *Repository) RestoreFromTrash(entry TrashEntry, path string) (*Worktree, error) {
+	if path == "" {
+		path = entry.Path
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		return nil, i18n.Errorf("目录已存在: %s", path)
+	}
+
+	options := CreateWorktreeOptions{
+		Branch: entry.Branch,
+		Path:   path,
+	}
+
+	switch {
+	case entry.Branch == "":
+		options.Detach = true
+		options.BaseBranch = entry.Head
+	default:
+		exists, err := r.BranchExists(entry.Branch)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			// 分支已被删除，从恢复引用重新创建
+			options.CreateBranch = true
+			options.BaseBranch = entry.Ref
+		}
+	}
+
+	worktree, err := r.CreateWorktree(options)
+	if err != nil {
+		return nil, err
+	}
+
+	archive := filepath.Join(r.TrashDir(), entry.ID, trashArchive)
+	if _, err := os.Stat(archive); err == nil {
+		if err := extractArchive(archive, path); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, file := range entry.Deleted {
+		os.Remove(filepath.Join(path, file))
+	}
+
+	r.dropTrash(&entry)
+
+	return worktree, nil
+}
+
+// dropTrash 删除回收站条目及其恢复引用
+func (r *Repository) dropTrash(entry *TrashEntry) {
+	if entry.Head != "" {
+		r.run("", "update-ref", "-d", entry.Ref)
+	}
+
+	os.RemoveAll(filepath.Join(r.TrashDir(), entry.ID))
+}
+
+// writeArchive 将 worktree 中的指定文件打包为 tar.gz
+func writeArchive(archive, root string, files []string) error {
+	out, err := os.Create(archive)
+	if err != nil {
+		return i18n.Errorf("创建归档失败: %w", err)
+	}
+	defer out.Close()
+
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+
+	for _, file := range files {
+		if err := addToArchive(tw, root, file); err != nil {
+			return i18n.Errorf("归档 %s 失败: %w", file, err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// addToArchive 将单个文件写入归档
+func addToArchive(tw *tar.Writer, root, file string) error {
+	fullPath := filepath.Join(root, file)
+	info, err := os.Lstat(fullPath)
+	if err != nil {
+		return err
+	}
+
+	link := ""
+	if info.Mode()&os.ModeSymlink != 0 {
+		if link, err = os.Readlink(fullPath); err != nil {
+			return err
+		}
+	}
+
+	header, err := tar.FileInfoHeader(info, link)
+	if err != nil {
+		return err
+	}
+	header.Name = filepath.ToSlash(file)
+
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+
+	if !info.Mode().IsRegular() {
+		return nil
+	}
+
+	f, err := os.Open(fullPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// extractArchive 将归档解压到 worktree
+func extractArchive(archive, root string) error {
+	in, err := os.Open(archive)
+	if err != nil {
+		return i18n.Errorf("打开归档失败: %w", err)
+	}
+	defer in.Close()
+
+	gz, err := gzip.NewReader(in)
+	if err != nil {
+		return i18n.Errorf("读取归档失败: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return i18n.Errorf("读取归档失败: %w", err)
+		}
+
+		target := filepath.Join(root, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(target, filepath.Clean(root)+string(os.PathSeparator)) {
+			return i18n.Errorf("归档中包含非法路径: %s", header.Name)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeSymlink:
+			os.Remove(target)
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode))
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(f, tr); err != nil {
+				f.Close()
+				return err
+			}
+			f.Close()
+		}
+	}
+}
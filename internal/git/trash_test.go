@@ -0,0 +1,76 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMoveToTrashDetachedWorktrees(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	setGitEnv(t)
+
+	root := tempDir(t)
+	main := filepath.Join(root, "repo")
+	initRepo(t, main)
+
+	repo, err := OpenRepository(main)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 目录名相同的两个分离 HEAD worktree，在同一秒内删除时条目 ID 的基础部分相同
+	paths := []string{filepath.Join(root, "a", "scratch"), filepath.Join(root, "b", "scratch")}
+	for _, path := range paths {
+		gitRun(t, main, "worktree", "add", "--quiet", "--detach", path)
+		if err := os.WriteFile(filepath.Join(path, "notes.txt"), []byte(path), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ids := make(map[string]bool)
+	for _, path := range paths {
+		changes, err := repo.InspectWorktree(path, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		entry, err := repo.MoveToTrash(WorktreeInfo{Path: path}, changes)
+		if err != nil {
+			t.Fatalf("MoveToTrash(%s): %v", path, err)
+		}
+		if ids[entry.ID] {
+			t.Fatalf("MoveToTrash(%s) reused trash id %s", path, entry.ID)
+		}
+		ids[entry.ID] = true
+
+		if err := repo.RemoveWorktree(path, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := repo.TrashEntries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != len(paths) {
+		t.Fatalf("TrashEntries() returned %d entries, want %d", len(entries), len(paths))
+	}
+	refs := gitRun(t, main, "for-each-ref", "--format=%(refname)", "refs/gwt/trash/")
+	if refs == "" || len(strings.Split(refs, "\n")) != len(paths) {
+		t.Errorf("trash refs = %q, want %d refs", refs, len(paths))
+	}
+
+	for _, entry := range entries {
+		if _, err := repo.RestoreFromTrash(entry, ""); err != nil {
+			t.Fatalf("RestoreFromTrash(%s): %v", entry.ID, err)
+		}
+		data, err := os.ReadFile(filepath.Join(entry.Path, "notes.txt"))
+		if err != nil || string(data) != entry.Path {
+			t.Errorf("restored %s/notes.txt = %q, %v, want %q", entry.Path, data, err, entry.Path)
+		}
+	}
+}
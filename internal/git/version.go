@@ -0,0 +1,78 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+// Version 表示 git 版本号
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// worktree 相关功能要求的最低 git 版本
+var (
+	// MinVersion 是 gwt 能正常工作的最低版本，git worktree remove 从 2.17 开始提供
+	MinVersion = Version{2, 17, 0}
+	// RecommendedVersion 起 git worktree list 会标记可清理的 worktree
+	RecommendedVersion = Version{2, 31, 0}
+	// OrphanVersion 起 git worktree add 支持 --orphan，更低的版本由 gwt 模拟
+	OrphanVersion = Version{2, 42, 0}
+)
+
+var versionPattern = regexp.MustCompile(`(\d+)\.(\d+)(?:\.(\d+))?`)
+
+// ParseVersion 解析 git --version 的输出，如 "git version 2.39.5 (Apple Git-154)"
+func ParseVersion(output string) (Version, error) {
+	m := versionPattern.FindStringSubmatch(output)
+	if m == nil {
+		return Version{}, i18n.Errorf("无法识别 git 版本: %s", output)
+	}
+
+	var v Version
+	v.Major, _ = strconv.Atoi(m[1])
+	v.Minor, _ = strconv.Atoi(m[2])
+	if m[3] != "" {
+		v.Patch, _ = strconv.Atoi(m[3])
+	}
+	return v, nil
+}
+
+// GetVersion 获取 git 版本，runner 为 nil 时使用 DefaultRunner
+func GetVersion(ctx context.Context, runner Runner) (Version, error) {
+	if runner == nil {
+		runner = DefaultRunner
+	}
+
+	result, err := runner.Run(ctx, Command{Args: []string{"--version"}})
+	if err != nil {
+		return Version{}, i18n.Errorf("获取 git 版本失败: %w", err)
+	}
+	return ParseVersion(string(result.Stdout))
+}
+
+// Version 获取执行仓库命令所用的 git 的版本
+func (r *Repository) Version() (Version, error) {
+	return GetVersion(r.ctx, r.runner)
+}
+
+// AtLeast 版本是否不低于 other
+func (v Version) AtLeast(other Version) bool {
+	if v.Major != other.Major {
+		return v.Major > other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor > other.Minor
+	}
+	return v.Patch >= other.Patch
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
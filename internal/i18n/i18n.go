@@ -0,0 +1,157 @@
+// Package i18n 提供界面文字的多语言支持
+//
+// 源代码中的中文字符串既是 zh-CN 的文字，也是消息目录的键；
+// 其他语言的目录以 JSON 形式嵌入在 locales/ 中，把中文原文映射为译文。
+// 目录中没有的字符串原样输出。
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// Chinese 是源代码使用的语言
+	Chinese = "zh-CN"
+	// English 是英文
+	English = "en"
+)
+
+//go:embed locales/*.json
+var locales embed.FS
+
+var (
+	mu       sync.RWMutex
+	language = Chinese
+	catalog  map[string]string
+)
+
+// Languages 返回支持的语言
+func Languages() []string {
+	languages := []string{Chinese}
+
+	entries, _ := locales.ReadDir("locales")
+	for _, entry := range entries {
+		languages = append(languages, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+
+	sort.Strings(languages)
+	return languages
+}
+
+// Normalize 将 en_US.UTF-8、zh_CN、zh-Hans 这样的语言标识转换为支持的语言，
+// 无法识别时返回空字符串
+func Normalize(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, ".@"); i >= 0 {
+		tag = tag[:i]
+	}
+
+	switch {
+	case tag == "", tag == "c", tag == "posix":
+		return ""
+	case strings.HasPrefix(tag, "zh"):
+		return Chinese
+	case strings.HasPrefix(tag, "en"):
+		return English
+	}
+	return ""
+}
+
+// Detect 按优先级确定界面语言：命令行参数、配置项，最后是 LC_ALL、LC_MESSAGES、LANG
+func Detect(flag, configured string) string {
+	candidates := []string{flag, configured, os.Getenv("LC_ALL"), os.Getenv("LC_MESSAGES"), os.Getenv("LANG")}
+	for _, candidate := range candidates {
+		if lang := Normalize(candidate); lang != "" {
+			return lang
+		}
+	}
+	return Chinese
+}
+
+// SetLanguage 设置界面语言
+func SetLanguage(lang string) error {
+	normalized := Normalize(lang)
+	if normalized == "" {
+		return fmt.Errorf("不支持的语言: %s（支持: %s）", lang, strings.Join(Languages(), ", "))
+	}
+
+	var messages map[string]string
+	if normalized != Chinese {
+		data, err := locales.ReadFile("locales/" + normalized + ".json")
+		if err != nil {
+			return fmt.Errorf("不支持的语言: %s（支持: %s）", lang, strings.Join(Languages(), ", "))
+		}
+		if err := json.Unmarshal(data, &messages); err != nil {
+			return fmt.Errorf("解析语言文件 %s 失败: %w", normalized, err)
+		}
+	}
+
+	mu.Lock()
+	language = normalized
+	catalog = messages
+	mu.Unlock()
+
+	return nil
+}
+
+// Language 返回当前界面语言
+func Language() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return language
+}
+
+// lookup 返回消息在当前语言中的文字
+func lookup(msg string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if translated, ok := catalog[msg]; ok && translated != "" {
+		return translated
+	}
+	return msg
+}
+
+// T 翻译消息；带参数时把译文作为格式字符串
+func T(msg string, args ...interface{}) string {
+	if len(args) == 0 {
+		return lookup(msg)
+	}
+	return fmt.Sprintf(lookup(msg), args...)
+}
+
+// Errorf 与 fmt.Errorf 相同，格式字符串先经过翻译
+func Errorf(format string, args ...interface{}) error {
+	return fmt.Errorf(lookup(format), args...)
+}
+
+// Printf 与 fmt.Printf 相同，格式字符串先经过翻译
+func Printf(format string, args ...interface{}) {
+	fmt.Print(T(format, args...))
+}
+
+// Fprintf 与 fmt.Fprintf 相同，格式字符串先经过翻译
+func Fprintf(w io.Writer, format string, args ...interface{}) {
+	fmt.Fprint(w, T(format, args...))
+}
+
+// lazyError 是在输出时才翻译的错误，用于在确定语言之前创建的哨兵错误
+type lazyError struct {
+	msg string
+}
+
+func (e *lazyError) Error() string {
+	return lookup(e.msg)
+}
+
+// Error 与 errors.New 相同，但错误信息在输出时按当前语言翻译
+func Error(msg string) error {
+	return &lazyError{msg}
+}
@@ -0,0 +1,116 @@
+package multiplexer
+
+import (
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+// Mode 表示打开 worktree 的方式
+type Mode string
+
+const (
+	// ModeSession 为每个 worktree 创建独立的会话
+	ModeSession Mode = "session"
+	// ModeWindow 在当前（或仓库）会话中为 worktree 创建窗口/标签页
+	ModeWindow Mode = "window"
+)
+
+// Pane 表示布局中的一个窗格
+type Pane struct {
+	Command string `mapstructure:"command"`
+	Split   string `mapstructure:"split"` // horizontal 或 vertical，第一个窗格忽略
+}
+
+// Layout 表示窗格布局
+type Layout struct {
+	Arrange string `mapstructure:"arrange"` // tmux select-layout 的布局名，如 tiled、main-vertical
+	Panes   []Pane `mapstructure:"panes"`
+}
+
+// Target 表示要打开的目标
+type Target struct {
+	Session string
+	Window  string
+	Dir     string
+	Mode    Mode
+	Layout  Layout
+}
+
+// Multiplexer 终端复用器接口
+type Multiplexer interface {
+	// Name 返回复用器名称
+	Name() string
+	// Available 检查复用器是否已安装
+	Available() bool
+	// Inside 检查当前是否运行在该复用器内
+	Inside() bool
+	// Open 创建或附加到目标会话/窗口
+	Open(target Target) error
+}
+
+// registry 已注册的复用器
+var registry = map[string]func() Multiplexer{
+	"tmux":   func() Multiplexer { return &Tmux{} },
+	"zellij": func() Multiplexer { return &Zellij{} },
+}
+
+// Get 根据名称获取复用器
+func Get(name string) (Multiplexer, error) {
+	factory, ok := registry[name]
+	if !ok {
+		return nil, i18n.Errorf("不支持的终端复用器: %s（支持: %s）", name, strings.Join(Names(), ", "))
+	}
+
+	mux := factory()
+	if !mux.Available() {
+		return nil, i18n.Errorf("终端复用器 '%s' 未安装或不在 PATH 中", name)
+	}
+
+	return mux, nil
+}
+
+// Detect 返回当前所在的复用器，不在任何复用器中时返回 nil
+func Detect() Multiplexer {
+	for _, name := range Names() {
+		mux := registry[name]()
+		if mux.Inside() {
+			return mux
+		}
+	}
+	return nil
+}
+
+// Names 返回所有支持的复用器名称
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SanitizeName 将名称转换为复用器可接受的会话/窗口名
+func SanitizeName(name string) string {
+	replacer := strings.NewReplacer(".", "_", ":", "_", " ", "_")
+	return replacer.Replace(name)
+}
+
+// isAvailable 检查命令是否在 PATH 中
+func isAvailable(command string) bool {
+	_, err := exec.LookPath(command)
+	return err == nil
+}
+
+// runInteractive 运行需要接管终端的命令
+func runInteractive(name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,205 @@
+package multiplexer
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+// Tmux tmux 终端复用器
+type Tmux struct{}
+
+// Name 返回复用器名称
+func (t *Tmux) Name() string {
+	return "tmux"
+}
+
+// Available 检查 tmux 是否已安装
+func (t *Tmux) Available() bool {
+	return isAvailable("tmux")
+}
+
+// Inside 检查当前是否在 tmux 中
+func (t *Tmux) Inside() bool {
+	return os.Getenv("TMUX") != ""
+}
+
+// Open 创建或附加到 tmux 会话/窗口
+func (t *Tmux) Open(target Target) error {
+	if target.Mode == ModeWindow {
+		return t.openWindow(target)
+	}
+	return t.openSession(target)
+}
+
+// openSession 为 worktree 创建或附加独立会话
+func (t *Tmux) openSession(target Target) error {
+	session := SanitizeName(target.Session)
+
+	if !t.hasSession(session) {
+		windowID, err := t.output("new-session", "-d", "-s", session, "-n", SanitizeName(target.Window),
+			"-c", target.Dir, "-P", "-F", "#{window_id}")
+		if err != nil {
+			return i18n.Errorf("创建 tmux 会话失败: %w", err)
+		}
+
+		if err := t.applyLayout(windowID, target); err != nil {
+			return err
+		}
+	}
+
+	return t.attach(session)
+}
+
+// openWindow 在会话中为 worktree 创建或选择窗口
+func (t *Tmux) openWindow(target Target) error {
+	session := SanitizeName(target.Session)
+	if t.Inside() {
+		current, err := t.output("display-message", "-p", "#S")
+		if err != nil {
+			return i18n.Errorf("获取当前 tmux 会话失败: %w", err)
+		}
+		session = current
+	}
+
+	window := SanitizeName(target.Window)
+
+	if !t.hasSession(session) {
+		windowID, err := t.output("new-session", "-d", "-s", session, "-n", window,
+			"-c", target.Dir, "-P", "-F", "#{window_id}")
+		if err != nil {
+			return i18n.Errorf("创建 tmux 会话失败: %w", err)
+		}
+
+		if err := t.applyLayout(windowID, target); err != nil {
+			return err
+		}
+
+		return t.attach(session)
+	}
+
+	windowID, err := t.findWindow(session, window)
+	if err != nil {
+		return err
+	}
+
+	if windowID == "" {
+		windowID, err = t.output("new-window", "-d", "-t", "="+session+":", "-n", window,
+			"-c", target.Dir, "-P", "-F", "#{window_id}")
+		if err != nil {
+			return i18n.Errorf("创建 tmux 窗口失败: %w", err)
+		}
+
+		if err := t.applyLayout(windowID, target); err != nil {
+			return err
+		}
+	}
+
+	if err := t.run("select-window", "-t", windowID); err != nil {
+		return i18n.Errorf("选择 tmux 窗口失败: %w", err)
+	}
+
+	return t.attach(session)
+}
+
+// attach 在 tmux 内切换客户端，在 tmux 外附加会话
+func (t *Tmux) attach(session string) error {
+	if t.Inside() {
+		if err := t.run("switch-client", "-t", "="+session); err != nil {
+			return i18n.Errorf("切换 tmux 会话失败: %w", err)
+		}
+		return nil
+	}
+
+	if err := runInteractive("tmux", "attach-session", "-t", "="+session); err != nil {
+		return i18n.Errorf("附加 tmux 会话失败: %w", err)
+	}
+	return nil
+}
+
+// applyLayout 在窗口中按布局创建窗格并运行命令
+func (t *Tmux) applyLayout(windowID string, target Target) error {
+	if len(target.Layout.Panes) == 0 {
+		return nil
+	}
+
+	var firstPane string
+	for i, pane := range target.Layout.Panes {
+		var paneID string
+		var err error
+
+		if i == 0 {
+			paneID, err = t.output("display-message", "-p", "-t", windowID, "#{pane_id}")
+		} else {
+			args := []string{"split-window", "-d", "-t", windowID, "-c", target.Dir, "-P", "-F", "#{pane_id}"}
+			if pane.Split == "horizontal" {
+				args = append(args, "-h")
+			} else {
+				args = append(args, "-v")
+			}
+			paneID, err = t.output(args...)
+		}
+		if err != nil {
+			return i18n.Errorf("创建 tmux 窗格失败: %w", err)
+		}
+
+		if i == 0 {
+			firstPane = paneID
+		}
+
+		if pane.Command != "" {
+			if err := t.run("send-keys", "-t", paneID, pane.Command, "Enter"); err != nil {
+				return i18n.Errorf("在窗格中运行命令失败: %w", err)
+			}
+		}
+	}
+
+	if target.Layout.Arrange != "" {
+		if err := t.run("select-layout", "-t", windowID, target.Layout.Arrange); err != nil {
+			return i18n.Errorf("应用 tmux 布局失败: %w", err)
+		}
+	}
+
+	return t.run("select-pane", "-t", firstPane)
+}
+
+// hasSession 检查会话是否存在
+func (t *Tmux) hasSession(session string) bool {
+	return t.run("has-session", "-t", "="+session) == nil
+}
+
+// findWindow 按名称查找会话中的窗口，返回窗口 ID
+func (t *Tmux) findWindow(session, window string) (string, error) {
+	output, err := t.output("list-windows", "-t", "="+session, "-F", "#{window_id} #{window_name}")
+	if err != nil {
+		return "", i18n.Errorf("获取 tmux 窗口列表失败: %w", err)
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		parts := strings.SplitN(line, " ", 2)
+		if len(parts) == 2 && parts[1] == window {
+			return parts[0], nil
+		}
+	}
+
+	return "", nil
+}
+
+// run 执行 tmux 命令
+func (t *Tmux) run(args ...string) error {
+	_, err := t.output(args...)
+	return err
+}
+
+// output 执行 tmux 命令并返回输出
+func (t *Tmux) output(args ...string) (string, error) {
+	cmd := exec.Command("tmux", args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
@@ -0,0 +1,196 @@
+package multiplexer
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+// Zellij zellij 终端复用器
+type Zellij struct{}
+
+// Name 返回复用器名称
+func (z *Zellij) Name() string {
+	return "zellij"
+}
+
+// Available 检查 zellij 是否已安装
+func (z *Zellij) Available() bool {
+	return isAvailable("zellij")
+}
+
+// Inside 检查当前是否在 zellij 中
+func (z *Zellij) Inside() bool {
+	return os.Getenv("ZELLIJ") != ""
+}
+
+// Open 创建或附加到 zellij 会话/标签页
+//
+// zellij 无法从命令行切换当前客户端的会话，因此在 zellij 内
+// 无论哪种模式都会在当前会话中打开一个标签页。
+func (z *Zellij) Open(target Target) error {
+	layoutFile, err := z.writeLayout(target)
+	if err != nil {
+		return err
+	}
+	if layoutFile != "" {
+		defer os.Remove(layoutFile)
+	}
+
+	if z.Inside() {
+		name := target.Window
+		if target.Mode == ModeSession {
+			name = target.Session
+		}
+		return z.openTab(os.Getenv("ZELLIJ_SESSION_NAME"), SanitizeName(name), target.Dir, layoutFile)
+	}
+
+	session := SanitizeName(target.Session)
+	if !z.hasSession(session) {
+		args := []string{"--session", session}
+		if layoutFile != "" {
+			args = append(args, "--layout", layoutFile)
+		}
+
+		cmd := exec.Command("zellij", args...)
+		cmd.Dir = target.Dir
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return i18n.Errorf("创建 zellij 会话失败: %w", err)
+		}
+		return nil
+	}
+
+	if target.Mode == ModeWindow {
+		if err := z.openTab(session, SanitizeName(target.Window), target.Dir, layoutFile); err != nil {
+			return err
+		}
+	}
+
+	if err := runInteractive("zellij", "attach", session); err != nil {
+		return i18n.Errorf("附加 zellij 会话失败: %w", err)
+	}
+	return nil
+}
+
+// openTab 在会话中打开标签页，已存在同名标签页时直接切换
+func (z *Zellij) openTab(session, name, dir, layoutFile string) error {
+	if z.hasTab(session, name) {
+		if err := z.action(session, "go-to-tab-name", name); err != nil {
+			return i18n.Errorf("切换 zellij 标签页失败: %w", err)
+		}
+		return nil
+	}
+
+	args := []string{"new-tab", "--name", name, "--cwd", dir}
+	if layoutFile != "" {
+		args = append(args, "--layout", layoutFile)
+	}
+
+	if err := z.action(session, args...); err != nil {
+		return i18n.Errorf("创建 zellij 标签页失败: %w", err)
+	}
+	return nil
+}
+
+// hasSession 检查会话是否存在
+func (z *Zellij) hasSession(session string) bool {
+	output, err := exec.Command("zellij", "list-sessions", "--short", "--no-formatting").Output()
+	if err != nil {
+		// 旧版本 zellij 不支持 --short，退回到解析完整输出
+		output, err = exec.Command("zellij", "list-sessions").Output()
+		if err != nil {
+			return false
+		}
+	}
+
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) > 0 && fields[0] == session {
+			return true
+		}
+	}
+	return false
+}
+
+// hasTab 检查会话中是否存在同名标签页
+func (z *Zellij) hasTab(session, name string) bool {
+	args := []string{"action", "query-tab-names"}
+	if session != "" {
+		args = append([]string{"--session", session}, args...)
+	}
+
+	output, err := exec.Command("zellij", args...).Output()
+	if err != nil {
+		return false
+	}
+
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.TrimSpace(line) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// action 执行 zellij action 子命令
+func (z *Zellij) action(session string, args ...string) error {
+	fullArgs := append([]string{"action"}, args...)
+	if session != "" {
+		fullArgs = append([]string{"--session", session}, fullArgs...)
+	}
+
+	output, err := exec.Command("zellij", fullArgs...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// writeLayout 将布局写入临时 KDL 文件，没有布局时返回空字符串
+func (z *Zellij) writeLayout(target Target) (string, error) {
+	if len(target.Layout.Panes) == 0 {
+		return "", nil
+	}
+
+	// zellij 的 split_direction 作用于父节点：vertical 表示子窗格左右排列，
+	// 对应 tmux 的水平拆分 (split-window -h)
+	direction := "horizontal"
+	if len(target.Layout.Panes) > 1 && target.Layout.Panes[1].Split == "horizontal" {
+		direction = "vertical"
+	}
+
+	var b strings.Builder
+	b.WriteString("layout {\n")
+	fmt.Fprintf(&b, "    pane split_direction=%s {\n", strconv.Quote(direction))
+	for _, pane := range target.Layout.Panes {
+		if pane.Command == "" {
+			fmt.Fprintf(&b, "        pane cwd=%s\n", strconv.Quote(target.Dir))
+			continue
+		}
+		fmt.Fprintf(&b, "        pane command=\"sh\" cwd=%s {\n", strconv.Quote(target.Dir))
+		fmt.Fprintf(&b, "            args \"-c\" %s\n", strconv.Quote(pane.Command+"; exec ${SHELL:-sh}"))
+		b.WriteString("        }\n")
+	}
+	b.WriteString("    }\n")
+	b.WriteString("}\n")
+
+	file, err := os.CreateTemp("", "gwt-zellij-*.kdl")
+	if err != nil {
+		return "", i18n.Errorf("创建 zellij 布局文件失败: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(b.String()); err != nil {
+		os.Remove(file.Name())
+		return "", i18n.Errorf("写入 zellij 布局文件失败: %w", err)
+	}
+
+	return file.Name(), nil
+}
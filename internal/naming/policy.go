@@ -0,0 +1,183 @@
+// Package naming 实现分支命名规则：允许的前缀、正则、最大长度、
+// 必须包含的工单号以及 git check-ref-format 之外的禁用字符。
+package naming
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+// ErrPolicyViolation 表示分支名不符合命名规则
+var ErrPolicyViolation = i18n.Error("分支名不符合命名规则")
+
+// DefaultTicketPattern 是未配置 ticket_pattern 时识别工单号的正则，如 ISSUE-123
+const DefaultTicketPattern = `[A-Z][A-Z0-9]+-[0-9]+`
+
+// Policy 分支命名规则，对应配置中的 branch_policy，零值表示不做任何限制
+type Policy struct {
+	// Prefixes 是允许的分支前缀，如 feature/、hotfix/
+	Prefixes []string `mapstructure:"prefixes"`
+	// Pattern 是分支名必须匹配的正则
+	Pattern string `mapstructure:"pattern"`
+	// MaxLength 是分支名的最大长度，0 表示不限制
+	MaxLength int `mapstructure:"max_length"`
+	// RequireTicket 要求分支名包含工单号
+	RequireTicket bool `mapstructure:"require_ticket"`
+	// TicketPattern 是识别工单号的正则，默认为 DefaultTicketPattern
+	TicketPattern string `mapstructure:"ticket_pattern"`
+	// ForbiddenChars 是除 git 本身限制之外禁止出现在分支名中的字符
+	ForbiddenChars string `mapstructure:"forbidden_chars"`
+}
+
+// IsZero 是否没有配置任何规则
+func (p *Policy) IsZero() bool {
+	return len(p.Prefixes) == 0 && p.Pattern == "" && p.MaxLength == 0 &&
+		!p.RequireTicket && p.ForbiddenChars == ""
+}
+
+// Compile 检查规则中的正则是否有效
+func (p *Policy) Compile() error {
+	if p.Pattern != "" {
+		if _, err := regexp.Compile(p.Pattern); err != nil {
+			return i18n.Errorf("branch_policy.pattern 不是有效的正则: %w", err)
+		}
+	}
+	if p.TicketPattern != "" {
+		if _, err := regexp.Compile(p.TicketPattern); err != nil {
+			return i18n.Errorf("branch_policy.ticket_pattern 不是有效的正则: %w", err)
+		}
+	}
+	return nil
+}
+
+// Validate 检查分支名，返回所有不符合的规则说明；调用前应先用 Compile 检查正则
+func (p *Policy) Validate(branch string) []string {
+	var violations []string
+
+	if chars := p.forbiddenIn(branch); chars != "" {
+		violations = append(violations, i18n.T("包含禁用字符: %s", chars))
+	}
+
+	if len(p.Prefixes) > 0 && p.prefixOf(branch) == "" {
+		violations = append(violations, i18n.T("必须以这些前缀之一开头: %s", strings.Join(p.Prefixes, ", ")))
+	}
+
+	if p.Pattern != "" {
+		if re, err := regexp.Compile(p.Pattern); err == nil && !re.MatchString(branch) {
+			violations = append(violations, i18n.T("必须匹配正则: %s", p.Pattern))
+		}
+	}
+
+	if p.MaxLength > 0 && len(branch) > p.MaxLength {
+		violations = append(violations, i18n.T("长度 %d 超过上限 %d", len(branch), p.MaxLength))
+	}
+
+	if p.RequireTicket && !p.ticket().MatchString(branch) {
+		violations = append(violations, i18n.T("必须包含工单号（匹配 %s）", p.ticket().String()))
+	}
+
+	return violations
+}
+
+// Suggest 尝试修正分支名：替换禁用字符和空白、补全前缀、截断长度
+//
+// 修正后仍不符合规则（例如缺少工单号）时返回空字符串。
+func (p *Policy) Suggest(branch string) string {
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(branch) {
+		if r <= ' ' || r == 0x7f || strings.ContainsRune(`~^:?*[\`+p.ForbiddenChars, r) {
+			b.WriteRune('-')
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	name := b.String()
+	name = strings.ReplaceAll(name, "..", ".")
+	name = strings.ReplaceAll(name, "@{", "-")
+	for strings.Contains(name, "--") {
+		name = strings.ReplaceAll(name, "--", "-")
+	}
+	name = strings.Trim(name, "-./")
+
+	if len(p.Prefixes) > 0 && p.prefixOf(name) == "" {
+		name = p.Prefixes[0] + name
+	}
+
+	if p.MaxLength > 0 && len(name) > p.MaxLength {
+		name = strings.TrimRight(name[:p.MaxLength], "-./")
+	}
+
+	if name == "" || name == branch || len(p.Validate(name)) > 0 {
+		return ""
+	}
+	return name
+}
+
+// prefixOf 返回分支名匹配的前缀，没有匹配时返回空字符串
+func (p *Policy) prefixOf(branch string) string {
+	for _, prefix := range p.Prefixes {
+		if strings.HasPrefix(branch, prefix) {
+			return prefix
+		}
+	}
+	return ""
+}
+
+// forbiddenIn 返回分支名中出现的禁用字符
+func (p *Policy) forbiddenIn(branch string) string {
+	var found []rune
+	for _, r := range p.ForbiddenChars {
+		if strings.ContainsRune(branch, r) && !strings.ContainsRune(string(found), r) {
+			found = append(found, r)
+		}
+	}
+	return string(found)
+}
+
+// ticket 返回识别工单号的正则
+func (p *Policy) ticket() *regexp.Regexp {
+	if p.TicketPattern != "" {
+		if re, err := regexp.Compile(p.TicketPattern); err == nil {
+			return re
+		}
+	}
+	return regexp.MustCompile(DefaultTicketPattern)
+}
+
+// PolicyError 描述分支名违反的规则和建议的名称
+type PolicyError struct {
+	Branch     string
+	Violations []string
+	// Suggestion 是修正后的分支名，无法自动修正时为空
+	Suggestion string
+}
+
+func (e *PolicyError) Error() string {
+	var b strings.Builder
+	b.WriteString(i18n.T("分支名 %q 不符合命名规则:", e.Branch))
+	for _, v := range e.Violations {
+		b.WriteString("\n  - ")
+		b.WriteString(v)
+	}
+	return b.String()
+}
+
+// Is 使 errors.Is(err, ErrPolicyViolation) 成立
+func (e *PolicyError) Is(target error) bool {
+	return target == ErrPolicyViolation
+}
+
+// Check 检查分支名，不符合规则时返回 *PolicyError
+func (p *Policy) Check(branch string) error {
+	violations := p.Validate(branch)
+	if len(violations) == 0 {
+		return nil
+	}
+	return &PolicyError{
+		Branch:     branch,
+		Violations: violations,
+		Suggestion: p.Suggest(branch),
+	}
+}
@@ -0,0 +1,234 @@
+// Package share 把主工作区中不受版本控制的文件（如 .env.local、.idea/）共享到其他 worktree
+package share
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+// Mode 是共享文件的方式
+type Mode string
+
+const (
+	// ModeSymlink 在 worktree 中创建指向主工作区文件的符号链接
+	ModeSymlink Mode = "symlink"
+	// ModeCopy 把主工作区的文件复制到 worktree
+	ModeCopy Mode = "copy"
+)
+
+// ParseMode 解析共享方式，为空时使用 ModeSymlink
+func ParseMode(s string) (Mode, error) {
+	switch Mode(s) {
+	case "", ModeSymlink:
+		return ModeSymlink, nil
+	case ModeCopy:
+		return ModeCopy, nil
+	}
+	return "", i18n.Errorf("不支持的共享方式: %s（支持: symlink, copy）", s)
+}
+
+// State 是 worktree 中共享文件的状态
+type State string
+
+const (
+	// StateOK 表示文件已共享且与主工作区一致
+	StateOK State = "ok"
+	// StateMissing 表示 worktree 中没有这个文件
+	StateMissing State = "missing"
+	// StateModified 表示 worktree 中的文件与主工作区不一致，例如复制后被修改，
+	// 或者符号链接指向了其他位置
+	StateModified State = "modified"
+)
+
+// Collect 在 source 中展开 patterns，返回匹配到的文件相对于 source 的路径
+//
+// 模式按 filepath.Match 的语法逐级匹配（不支持 **），以 / 结尾或匹配到目录时
+// 包括目录下的所有文件。.git 目录中的文件不会被包括。
+func Collect(source string, patterns []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var files []string
+
+	add := func(path string) {
+		rel, err := filepath.Rel(source, path)
+		if err != nil || seen[rel] || isGitPath(rel) {
+			return
+		}
+		seen[rel] = true
+		files = append(files, rel)
+	}
+
+	for _, pattern := range patterns {
+		pattern = strings.Trim(filepath.FromSlash(pattern), string(filepath.Separator))
+		if pattern == "" {
+			continue
+		}
+
+		matches, err := filepath.Glob(filepath.Join(source, pattern))
+		if err != nil {
+			return nil, i18n.Errorf("无效的共享文件模式 %s: %w", pattern, err)
+		}
+
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil {
+				continue
+			}
+			if !info.IsDir() {
+				add(match)
+				continue
+			}
+
+			err = filepath.WalkDir(match, func(path string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if d.IsDir() && d.Name() == ".git" {
+					return filepath.SkipDir
+				}
+				if !d.IsDir() {
+					add(path)
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, i18n.Errorf("读取 %s 失败: %w", match, err)
+			}
+		}
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// Inspect 检查 target 中的文件 rel 与 source 中的是否一致
+func Inspect(source, target, rel string, mode Mode) (State, error) {
+	src := filepath.Join(source, rel)
+	dst := filepath.Join(target, rel)
+
+	info, err := os.Lstat(dst)
+	if os.IsNotExist(err) {
+		return StateMissing, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if mode == ModeSymlink {
+		if info.Mode()&os.ModeSymlink == 0 {
+			return StateModified, nil
+		}
+		link, err := os.Readlink(dst)
+		if err != nil {
+			return "", err
+		}
+		if link != src {
+			return StateModified, nil
+		}
+		return StateOK, nil
+	}
+
+	if !info.Mode().IsRegular() {
+		return StateModified, nil
+	}
+	same, err := sameContent(src, dst)
+	if err != nil {
+		return "", err
+	}
+	if !same {
+		return StateModified, nil
+	}
+	return StateOK, nil
+}
+
+// Apply 把 source 中的文件 rel 共享到 target，返回应用前的状态
+//
+// 文件已经一致时不做任何操作；状态为 StateModified 时只有 force 为 true
+// 才会替换 target 中的文件。
+func Apply(source, target, rel string, mode Mode, force bool) (State, error) {
+	state, err := Inspect(source, target, rel, mode)
+	if err != nil {
+		return "", err
+	}
+	if state == StateOK || (state == StateModified && !force) {
+		return state, nil
+	}
+
+	src := filepath.Join(source, rel)
+	dst := filepath.Join(target, rel)
+
+	if state == StateModified {
+		if err := os.Remove(dst); err != nil {
+			return "", err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return "", err
+	}
+
+	if mode == ModeSymlink {
+		return state, os.Symlink(src, dst)
+	}
+	return state, copyFile(src, dst)
+}
+
+// copyFile 复制文件内容和权限位
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// sameContent 比较两个文件的内容是否相同
+func sameContent(a, b string) (bool, error) {
+	infoA, err := os.Stat(a)
+	if err != nil {
+		return false, err
+	}
+	infoB, err := os.Stat(b)
+	if err != nil {
+		return false, err
+	}
+	if infoA.Size() != infoB.Size() {
+		return false, nil
+	}
+
+	dataA, err := os.ReadFile(a)
+	if err != nil {
+		return false, err
+	}
+	dataB, err := os.ReadFile(b)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(dataA, dataB), nil
+}
+
+// isGitPath 判断相对路径是否位于 .git 中
+func isGitPath(rel string) bool {
+	first := strings.SplitN(filepath.ToSlash(rel), "/", 2)[0]
+	return first == ".git"
+}
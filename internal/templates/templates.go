@@ -0,0 +1,142 @@
+// Package templates 实现 worktree 模板：按任务类型（hotfix、feature、review 等）
+// 预先定义分支命名、基准分支、路径、创建后执行的钩子和默认编辑器。
+package templates
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+// 模板的来源
+const (
+	SourceUser    = "user"
+	SourceProject = "project"
+)
+
+// Template 描述一类任务的 worktree 创建方式
+//
+// Branch 和 Path 是 Go 模板，可以使用 Vars 中的字段，例如
+// "hotfix/{{.Name}}"、"../{{.Repo}}-{{.Name}}"。
+type Template struct {
+	Name        string   `mapstructure:"-"`
+	Source      string   `mapstructure:"-"`
+	Description string   `mapstructure:"description"`
+	Branch      string   `mapstructure:"branch"`
+	Base        string   `mapstructure:"base"`
+	Fetch       bool     `mapstructure:"fetch"`
+	Path        string   `mapstructure:"path"`
+	Hooks       []string `mapstructure:"hooks"`
+	Editor      string   `mapstructure:"editor"`
+}
+
+// Vars 是展开分支名和路径时可用的变量
+type Vars struct {
+	// Name 是命令行中给出的名称，如 ISSUE-123
+	Name string
+	// Template 是模板名
+	Template string
+	// Repo 是仓库名
+	Repo string
+	// Date 是当天日期，格式为 20060102
+	Date string
+	// User 是当前用户名
+	User string
+}
+
+// NewVars 创建展开模板使用的变量
+func NewVars(template, name, repo string) Vars {
+	user := os.Getenv("USER")
+	if user == "" {
+		user = os.Getenv("USERNAME")
+	}
+	return Vars{
+		Name:     name,
+		Template: template,
+		Repo:     repo,
+		Date:     time.Now().Format("20060102"),
+		User:     user,
+	}
+}
+
+// Merge 合并多个来源的模板，后面的来源覆盖前面的同名模板
+func Merge(sources ...map[string]Template) map[string]Template {
+	merged := make(map[string]Template)
+	for _, source := range sources {
+		for name, tpl := range source {
+			merged[name] = tpl
+		}
+	}
+	return merged
+}
+
+// Sorted 按名称排序返回模板
+func Sorted(all map[string]Template) []Template {
+	list := make([]Template, 0, len(all))
+	for name, tpl := range all {
+		tpl.Name = name
+		list = append(list, tpl)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// BranchName 展开分支名，模板没有定义时使用 <模板名>/<名称>
+func (t *Template) BranchName(vars Vars) (string, error) {
+	pattern := t.Branch
+	if pattern == "" {
+		pattern = "{{.Template}}/{{.Name}}"
+	}
+	return Expand(pattern, vars)
+}
+
+// WorktreePath 展开 worktree 路径，模板没有定义时返回空字符串
+func (t *Template) WorktreePath(vars Vars) (string, error) {
+	if t.Path == "" {
+		return "", nil
+	}
+	return Expand(t.Path, vars)
+}
+
+// Expand 用 vars 展开 Go 模板字符串
+func Expand(pattern string, vars Vars) (string, error) {
+	tpl, err := template.New("").Option("missingkey=error").Parse(pattern)
+	if err != nil {
+		return "", i18n.Errorf("解析模板 %q 失败: %w", pattern, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, vars); err != nil {
+		return "", i18n.Errorf("展开模板 %q 失败: %w", pattern, err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// RunHook 通过 $SHELL -c 在 dir 中执行钩子命令
+func RunHook(ctx context.Context, hook, dir string, env []string, stdout, stderr io.Writer) error {
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = "/bin/sh"
+	}
+
+	c := exec.CommandContext(ctx, shell, "-c", hook)
+	c.Dir = dir
+	c.Env = append(os.Environ(), env...)
+	c.Stdout = stdout
+	c.Stderr = stderr
+
+	if err := c.Run(); err != nil {
+		return i18n.Errorf("执行钩子 %q 失败: %w", hook, err)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package ui
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+// ErrInputRequired 表示需要用户输入，但当前无法交互
+var ErrInputRequired = i18n.Error("需要用户输入，但当前是非交互模式")
+
+// Prompter 向用户提问，可以替换为测试实现
+type Prompter interface {
+	// Confirm 询问是/否问题，用户直接回车时返回 def
+	Confirm(question string, def bool) (bool, error)
+	// Input 读取一行输入，用户直接回车时返回 def
+	Input(question, def string) (string, error)
+}
+
+// PromptOptions 创建终端 Prompter 的选项
+type PromptOptions struct {
+	// Yes 对所有确认问题回答“是”，不读取输入
+	Yes bool
+	// NoInput 禁止读取输入：有默认值时使用默认值，否则返回 ErrInputRequired
+	NoInput bool
+}
+
+// TerminalPrompter 从终端读取回答
+type TerminalPrompter struct {
+	in          *bufio.Reader
+	out         io.Writer
+	yes         bool
+	interactive bool
+}
+
+// NewPrompter 创建从 in 读取、向 out 输出问题的 Prompter
+//
+// in 不是终端（例如在 CI 中或输入被重定向）时视为非交互模式。
+func NewPrompter(in io.Reader, out io.Writer, opts PromptOptions) *TerminalPrompter {
+	return &TerminalPrompter{
+		in:          bufio.NewReader(in),
+		out:         out,
+		yes:         opts.Yes,
+		interactive: !opts.NoInput && IsTerminal(in),
+	}
+}
+
+// IsTerminal 检查 r 是否连接到终端
+func IsTerminal(r interface{}) bool {
+	f, ok := r.(*os.File)
+	if !ok {
+		return false
+	}
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// Confirm 询问是/否问题
+//
+// 使用 --yes 时直接返回 true；非交互模式下默认值为“是”时返回 true，
+// 否则返回 ErrInputRequired，避免在无人值守时误执行或静默取消操作。
+func (p *TerminalPrompter) Confirm(question string, def bool) (bool, error) {
+	if p.yes {
+		return true, nil
+	}
+
+	hint := "[y/N]"
+	if def {
+		hint = "[Y/n]"
+	}
+
+	if !p.interactive {
+		if def {
+			return true, nil
+		}
+		return false, i18n.Errorf("%w: %s", ErrInputRequired, question)
+	}
+
+	for {
+		fmt.Fprintf(p.out, "%s %s: ", question, hint)
+		answer, err := p.readLine()
+		if err != nil {
+			return false, err
+		}
+
+		switch strings.ToLower(answer) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+	}
+}
+
+// Input 读取一行输入
+func (p *TerminalPrompter) Input(question, def string) (string, error) {
+	if !p.interactive {
+		if def != "" {
+			return def, nil
+		}
+		return "", i18n.Errorf("%w: %s", ErrInputRequired, question)
+	}
+
+	if def != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", question, def)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", question)
+	}
+
+	answer, err := p.readLine()
+	if err != nil {
+		return "", err
+	}
+	if answer == "" {
+		return def, nil
+	}
+	return answer, nil
+}
+
+// readLine 读取一行并去除首尾空白，输入已关闭时返回 ErrInputRequired
+func (p *TerminalPrompter) readLine() (string, error) {
+	line, err := p.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		if err == io.EOF {
+			return "", i18n.Errorf("%w: 输入已关闭", ErrInputRequired)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"fmt"
 	"os"
 
 	"github.com/tinsfox/gwt/cmd"
@@ -19,7 +18,6 @@ func main() {
 	cmd.SetVersionInfo(Version, BuildTime, GitCommit)
 
 	if err := cmd.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(cmd.ExitCode(err))
 	}
 }
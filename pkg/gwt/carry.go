@@ -0,0 +1,113 @@
+package gwt
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/tinsfox/gwt/internal/git"
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+// CarryOptions 在 worktree 之间转移未提交修改的选项
+type CarryOptions struct {
+	// Paths 限制转移的路径，相对于 worktree 根目录
+	Paths []string
+	// StagedOnly 只转移暂存区中的修改，在目标 worktree 中同样暂存
+	StagedOnly bool
+	// Move 应用成功后从源 worktree 中移除这些修改，默认保留
+	Move bool
+	// ThreeWay 无法直接应用时尝试三方合并，冲突以冲突标记写入目标 worktree
+	ThreeWay bool
+}
+
+// CarryResult 转移修改的结果
+type CarryResult struct {
+	From Worktree
+	To   Worktree
+	// Files 是转移的文件
+	Files []string
+	// Moved 表示已从源 worktree 中移除这些修改
+	Moved bool
+}
+
+// Carry 把 from 中未提交的修改应用到 to
+//
+// 修改以补丁的形式一次性应用：无法应用时目标 worktree 保持不变，并返回
+// *ApplyConflictError 列出冲突的文件。使用 ThreeWay 时冲突会写入目标
+// worktree，此时返回的 *ApplyConflictError 的 Merged 为 true，源 worktree
+// 不会被修改。使用 Move 时如果无法从源 worktree 中移除修改，目标 worktree
+// 中已应用的修改会被撤销。
+func (c *Client) Carry(ctx context.Context, from, to string, opts CarryOptions) (*CarryResult, error) {
+	repo, err := c.open(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	src, dst, err := c.findPair(repo, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if src.Path == dst.Path {
+		return nil, i18n.Errorf("源和目标是同一个 worktree: %s", src.Path)
+	}
+
+	patch, files, err := repo.ChangesPatch(src.Path, opts.StagedOnly, opts.Paths)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, ErrNothingToCarry
+	}
+
+	// 先确认能从源 worktree 中移除修改，避免应用后才发现无法移动
+	if opts.Move {
+		check := git.ApplyOptions{Reverse: true, Check: true, Index: opts.StagedOnly}
+		if err := repo.ApplyPatch(src.Path, patch, check); err != nil {
+			var conflict *git.ApplyConflictError
+			if errors.As(err, &conflict) {
+				return nil, i18n.Errorf("无法从源 worktree 中移除修改，这些文件在暂存之后还有修改: %s", strings.Join(conflict.Files, ", "))
+			}
+			return nil, err
+		}
+	}
+
+	result := &CarryResult{From: *src, To: *dst, Files: files}
+
+	apply := git.ApplyOptions{Index: opts.StagedOnly}
+	if err := repo.ApplyPatch(dst.Path, patch, apply); err != nil {
+		var conflict *git.ApplyConflictError
+		if !opts.ThreeWay || !errors.As(err, &conflict) {
+			return nil, err
+		}
+
+		apply.ThreeWay = true
+		if err := repo.ApplyPatch(dst.Path, patch, apply); err != nil {
+			return result, err
+		}
+	}
+
+	if opts.Move {
+		if err := removeChanges(repo, src.Path, patch, files, opts.StagedOnly); err != nil {
+			rollback := git.ApplyOptions{Reverse: true, Index: apply.Index || apply.ThreeWay}
+			if rerr := repo.ApplyPatch(dst.Path, patch, rollback); rerr != nil {
+				return nil, i18n.Errorf("从源 worktree 中移除修改失败: %v；撤销目标 worktree 中的修改也失败: %w", err, rerr)
+			}
+			return nil, i18n.Errorf("从源 worktree 中移除修改失败，已撤销目标 worktree 中的修改: %w", err)
+		}
+		result.Moved = true
+	}
+
+	return result, nil
+}
+
+// removeChanges 从 worktree 中移除补丁包含的修改，暂存区同时恢复为 HEAD
+func removeChanges(repo *git.Repository, path string, patch []byte, files []string, stagedOnly bool) error {
+	if err := repo.ApplyPatch(path, patch, git.ApplyOptions{Reverse: true, Index: stagedOnly}); err != nil {
+		return err
+	}
+	if stagedOnly {
+		return nil
+	}
+	return repo.ResetPaths(path, files)
+}
@@ -0,0 +1,138 @@
+// Package gwt 提供 gwt 的 Go 库接口，供其他工具嵌入 worktree 管理功能。
+//
+// Client 的方法不会提示用户输入，也不会退出进程：需要确认的操作
+// 通过选项显式授权，失败时返回可用 errors.Is / errors.As 判断的错误。
+//
+//	client := gwt.New(gwt.Options{Dir: "/path/to/repo"})
+//	worktrees, err := client.List(ctx)
+package gwt
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/tinsfox/gwt/internal/git"
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+// Worktree 表示 worktree 信息
+type Worktree = git.WorktreeInfo
+
+// Commit 表示提交信息
+type Commit = git.CommitInfo
+
+// Metadata 是 gwt 为 worktree 记录的说明、标签等信息
+type Metadata = git.Metadata
+
+// Changes 表示 worktree 中删除后会丢失的工作
+type Changes = git.WorktreeChanges
+
+// TrashEntry 表示回收站中的一个已删除 worktree
+type TrashEntry = git.TrashEntry
+
+// GitRunner 执行 git 命令，可以替换为自定义实现
+type GitRunner = git.Runner
+
+// GitCommand 描述一次 git 调用
+type GitCommand = git.Command
+
+// GitResult 表示 git 命令的执行结果
+type GitResult = git.Result
+
+// FakeGitRunner 按预设输出响应 git 命令，用于在测试中替代真实的 git
+type FakeGitRunner = git.FakeRunner
+
+// RecordingGitRunner 记录经过它执行的每条 git 命令
+type RecordingGitRunner = git.RecordingRunner
+
+// NewFakeGitRunner 创建 FakeGitRunner
+func NewFakeGitRunner() *FakeGitRunner {
+	return git.NewFakeRunner()
+}
+
+// CommandError 表示 git 命令执行失败
+type CommandError = git.CommandError
+
+// Options 创建 Client 的选项
+type Options struct {
+	// Dir 是仓库中的任意目录，默认为当前目录；相对路径参数也相对于它解析
+	Dir string
+	// Stdout、Stderr 接收 Client 代为执行的命令输出和警告，默认丢弃
+	Stdout io.Writer
+	Stderr io.Writer
+	// Stdin 是 Client 代为执行的命令的标准输入，默认为空
+	Stdin io.Reader
+	// Git 执行 git 命令，默认执行 GitPath 指定的 git 可执行文件
+	Git GitRunner
+	// GitPath 是 git 可执行文件路径，默认在 PATH 中查找；设置了 Git 时忽略
+	GitPath string
+	// Trace 非空时记录每条 git 命令及其退出码和耗时
+	Trace io.Writer
+}
+
+// Client 是 gwt 的库接口
+type Client struct {
+	dir    string
+	stdout io.Writer
+	stderr io.Writer
+	stdin  io.Reader
+	git    GitRunner
+}
+
+// New 创建 Client
+func New(opts Options) *Client {
+	c := &Client{
+		dir:    opts.Dir,
+		stdout: opts.Stdout,
+		stderr: opts.Stderr,
+		stdin:  opts.Stdin,
+		git:    opts.Git,
+	}
+
+	if c.dir == "" {
+		c.dir = "."
+	}
+	if c.stdout == nil {
+		c.stdout = io.Discard
+	}
+	if c.stderr == nil {
+		c.stderr = io.Discard
+	}
+	if c.stdin == nil {
+		c.stdin = strings.NewReader("")
+	}
+	if c.git == nil {
+		c.git = git.ExecRunner{Path: opts.GitPath}
+	}
+	if opts.Trace != nil {
+		c.git = git.TraceRunner{Runner: c.git, Out: opts.Trace}
+	}
+
+	return c
+}
+
+// open 打开 Client 所在的仓库
+func (c *Client) open(ctx context.Context) (*git.Repository, error) {
+	return git.Open(ctx, c.dir, c.git)
+}
+
+// abs 将相对路径转换为相对于 Client 目录的绝对路径
+func (c *Client) abs(path string) (string, error) {
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path), nil
+	}
+
+	dir, err := filepath.Abs(c.dir)
+	if err != nil {
+		return "", i18n.Errorf("转换路径失败: %w", err)
+	}
+	return filepath.Join(dir, path), nil
+}
+
+// warnf 输出不影响操作结果的警告
+func (c *Client) warnf(format string, args ...interface{}) {
+	fmt.Fprintf(c.stderr, "warning: "+format+"\n", args...)
+}
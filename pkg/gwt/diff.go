@@ -0,0 +1,132 @@
+package gwt
+
+import (
+	"context"
+
+	"github.com/tinsfox/gwt/internal/git"
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+// DiffOptions 比较两个 worktree 的选项
+type DiffOptions struct {
+	// WorkingTree 为 true 时比较两个 worktree 当前的文件，包括未提交的修改和
+	// 未跟踪文件；否则比较两个 worktree 的 HEAD 提交
+	WorkingTree bool
+	// Stat 只输出变更统计
+	Stat bool
+	// NameOnly 只输出变更的文件名
+	NameOnly bool
+	// Paths 限制比较的路径
+	Paths []string
+	// Color 输出带颜色的 diff
+	Color bool
+	// Tool 使用 git 配置的 difftool（diff.tool）以目录模式比较
+	Tool bool
+}
+
+// Comparison 两个 worktree 的提交差异
+type Comparison struct {
+	From Worktree
+	To   Worktree
+	// MergeBase 是两边的共同祖先，没有共同祖先时为空
+	MergeBase string
+	// OnlyFrom 是只在 From 中的提交，OnlyTo 是只在 To 中的提交，按时间倒序
+	OnlyFrom []Commit
+	OnlyTo   []Commit
+}
+
+// Diff 比较两个 worktree，输出写入 Client 的 Stdout
+//
+// from 和 to 按 Find 的规则匹配，为空时表示当前所在的 worktree。
+func (c *Client) Diff(ctx context.Context, from, to string, opts DiffOptions) error {
+	repo, err := c.open(ctx)
+	if err != nil {
+		return err
+	}
+
+	a, b, err := c.findPair(repo, from, to)
+	if err != nil {
+		return err
+	}
+
+	revA, err := diffRevision(repo, a, opts.WorkingTree)
+	if err != nil {
+		return err
+	}
+	revB, err := diffRevision(repo, b, opts.WorkingTree)
+	if err != nil {
+		return err
+	}
+
+	if opts.Tool {
+		return repo.DiffTool(revA, revB, opts.Paths, c.stdin, c.stdout, c.stderr)
+	}
+
+	return repo.Diff(revA, revB, git.DiffOptions{
+		Stat:     opts.Stat,
+		NameOnly: opts.NameOnly,
+		Color:    opts.Color,
+		Paths:    opts.Paths,
+	}, c.stdout)
+}
+
+// Compare 获取两个 worktree 各自独有的提交
+func (c *Client) Compare(ctx context.Context, from, to string) (*Comparison, error) {
+	repo, err := c.open(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	a, b, err := c.findPair(repo, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	headA, err := repo.RevParse(a.Path, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	headB, err := repo.RevParse(b.Path, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	onlyA, onlyB, err := repo.UniqueCommits(headA, headB)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Comparison{
+		From:      *a,
+		To:        *b,
+		MergeBase: repo.MergeBase(headA, headB),
+		OnlyFrom:  onlyA,
+		OnlyTo:    onlyB,
+	}, nil
+}
+
+// findPair 查找要比较的两个 worktree，只获取一次 worktree 列表
+func (c *Client) findPair(repo *git.Repository, from, to string) (*Worktree, *Worktree, error) {
+	worktrees, err := repo.GetWorktrees()
+	if err != nil {
+		return nil, nil, i18n.Errorf("获取 worktree 列表失败: %w", err)
+	}
+
+	a, err := c.findIn(repo, worktrees, from)
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err := c.findIn(repo, worktrees, to)
+	if err != nil {
+		return nil, nil, err
+	}
+	return a, b, nil
+}
+
+// diffRevision 返回比较时代表 worktree 的提交或 tree
+func diffRevision(repo *git.Repository, wt *Worktree, workingTree bool) (string, error) {
+	if workingTree {
+		return repo.SnapshotTree(wt.Path)
+	}
+	return repo.RevParse(wt.Path, "HEAD")
+}
@@ -0,0 +1,93 @@
+package gwt
+
+import (
+	"github.com/tinsfox/gwt/internal/git"
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+// 可以用 errors.Is 判断的错误
+var (
+	// ErrNotRepository 表示目录不在 Git 仓库中
+	ErrNotRepository = git.ErrNotRepository
+	// ErrNotFound 表示没有找到指定的 worktree
+	ErrNotFound = git.ErrNotFound
+	// ErrAmbiguousTarget 表示名称匹配到多个 worktree，详情见 *AmbiguousTargetError
+	ErrAmbiguousTarget = git.ErrAmbiguousTarget
+	// ErrMainWorktree 表示操作不能用于主工作区
+	ErrMainWorktree = git.ErrMainWorktree
+	// ErrBranchCheckedOut 表示分支已在其他 worktree 中检出，详情见 *BranchCheckedOutError
+	ErrBranchCheckedOut = git.ErrBranchCheckedOut
+	// ErrBranchExists 表示要创建的分支已存在
+	ErrBranchExists = git.ErrBranchExists
+	// ErrInvalidReference 表示分支或提交不存在
+	ErrInvalidReference = git.ErrInvalidReference
+	// ErrPathExists 表示 worktree 的目标目录已存在
+	ErrPathExists = git.ErrPathExists
+	// ErrWorktreeDirty 表示 git 因 worktree 中有修改而拒绝操作
+	ErrWorktreeDirty = git.ErrWorktreeDirty
+	// ErrLocked 表示 worktree 已被锁定，详情见 *LockedError
+	ErrLocked = git.ErrLocked
+	// ErrNotLocked 表示 worktree 没有被锁定
+	ErrNotLocked = git.ErrNotLocked
+	// ErrUnsavedWork 表示 worktree 中有删除后会丢失的工作，详情见 *UnsavedWorkError
+	ErrUnsavedWork = git.ErrWorktreeDirty
+	// ErrApplyConflict 表示修改无法干净地应用到目标 worktree，详情见 *ApplyConflictError
+	ErrApplyConflict = git.ErrApplyConflict
+	// ErrBranchNotMerged 表示分支尚未合并，删除会丢失提交，详情见 *BranchNotMergedError
+	ErrBranchNotMerged = i18n.Error("分支尚未合并")
+	// ErrNothingToCarry 表示源 worktree 中没有可以转移的修改
+	ErrNothingToCarry = i18n.Error("没有可以转移的修改")
+)
+
+// BranchCheckedOutError 表示分支已在其他 worktree 中检出
+type BranchCheckedOutError = git.BranchCheckedOutError
+
+// LockedError 表示 worktree 已被锁定
+type LockedError = git.LockedError
+
+// AmbiguousTargetError 表示名称匹配到多个 worktree
+type AmbiguousTargetError = git.AmbiguousTargetError
+
+// ApplyConflictError 表示修改无法干净地应用到目标 worktree
+type ApplyConflictError = git.ApplyConflictError
+
+// UnsavedWorkError 在删除有未保存工作的 worktree 时返回，
+// 包含会丢失的具体内容
+type UnsavedWorkError struct {
+	Worktree Worktree
+	Changes  *Changes
+}
+
+func (e *UnsavedWorkError) Error() string {
+	return i18n.T("worktree 有未保存的工作: %s", e.Worktree.Path)
+}
+
+// Is 使 errors.Is(err, ErrUnsavedWork) 成立
+func (e *UnsavedWorkError) Is(target error) bool {
+	return target == ErrUnsavedWork
+}
+
+// BranchNotMergedError 在删除未合并的分支时返回，分支被保留
+type BranchNotMergedError struct {
+	Branch string
+	// Base 是判断是否已合并使用的基准分支
+	Base string
+	// Remote 是被保留的远程分支（remote/branch）：本地分支只合并到了这个上游，
+	// 没有合并到 Base，为空表示本地分支没有合并到任何分支
+	Remote string
+}
+
+func (e *BranchNotMergedError) Error() string {
+	if e.Remote != "" && e.Base == "" {
+		return i18n.T("无法确定基准分支，已保留远程分支 %s", e.Remote)
+	}
+	if e.Remote != "" {
+		return i18n.T("分支 %s 尚未合并到 %s，已保留远程分支 %s", e.Branch, e.Base, e.Remote)
+	}
+	return i18n.T("分支 %s 尚未合并，已保留", e.Branch)
+}
+
+// Is 使 errors.Is(err, ErrBranchNotMerged) 成立
+func (e *BranchNotMergedError) Is(target error) bool {
+	return target == ErrBranchNotMerged
+}
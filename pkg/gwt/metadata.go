@@ -0,0 +1,70 @@
+package gwt
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+// MetadataUpdate 修改 worktree 元数据的选项，nil 或空的字段保持不变
+type MetadataUpdate struct {
+	// Description 设置说明，指向空字符串时清除
+	Description *string
+	// Ticket 设置关联的工单，指向空字符串时清除
+	Ticket *string
+	// Owner 设置负责人，指向空字符串时清除
+	Owner *string
+	// AddTags 是要添加的标签
+	AddTags []string
+	// RemoveTags 是要移除的标签
+	RemoveTags []string
+}
+
+// UpdateMetadata 修改 worktree 的元数据并返回修改后的 worktree
+func (c *Client) UpdateMetadata(ctx context.Context, target string, update MetadataUpdate) (*Worktree, error) {
+	repo, err := c.open(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wt, err := c.find(repo, target)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := repo.ReadMetadata(wt.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Description != nil {
+		meta.Description = *update.Description
+	}
+	if update.Ticket != nil {
+		meta.Ticket = *update.Ticket
+	}
+	if update.Owner != nil {
+		meta.Owner = *update.Owner
+	}
+	meta.Tags = updateTags(meta.Tags, update.AddTags, update.RemoveTags)
+
+	if err := repo.WriteMetadata(wt.Path, meta); err != nil {
+		return nil, err
+	}
+
+	wt.Metadata = meta
+	return wt, nil
+}
+
+// updateTags 添加和移除标签，保持原有顺序并去除重复和空白
+func updateTags(tags, add, remove []string) []string {
+	var result []string
+	for _, tag := range append(slices.Clone(tags), add...) {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || slices.Contains(result, tag) || slices.Contains(remove, tag) {
+			continue
+		}
+		result = append(result, tag)
+	}
+	return result
+}
@@ -0,0 +1,525 @@
+package gwt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tinsfox/gwt/internal/git"
+	"github.com/tinsfox/gwt/internal/i18n"
+)
+
+// CreateOptions 创建 worktree 的选项
+type CreateOptions struct {
+	// Branch 是要检出的分支，不存在时基于 Base 创建
+	Branch string
+	// Path 是 worktree 路径，默认使用分支名（裸仓库布局中与其他 worktree 并列）
+	Path string
+	// Base 是新分支的起点，默认为当前 HEAD
+	Base string
+	// Detach 以分离 HEAD 检出 Base（标签、提交或远程分支），不创建分支，
+	// 此时 Branch 必须为空，路径默认使用 Base
+	Detach bool
+	// Scratch 把 worktree 标记为临时 worktree，Clean 会删除这些 worktree
+	Scratch bool
+	// Force 在目标目录已存在时仍然创建
+	Force bool
+	// Sparse 非空时以 cone 模式只检出这些目录（以及根目录中的文件）
+	Sparse []string
+	// Orphan 在没有任何提交的新分支 Branch 上创建 worktree，分支已存在时返回 ErrBranchExists
+	Orphan bool
+	// Seed 非空时把该目录中的文件作为孤儿分支的初始提交
+	Seed string
+	// Metadata 是要记录的说明、标签等信息，CreatedAt 总是设置为创建时间
+	Metadata Metadata
+}
+
+// CreateResult 创建 worktree 的结果
+type CreateResult struct {
+	Worktree  Worktree
+	NewBranch bool // 是否创建了新分支
+}
+
+// RemoveOptions 删除 worktree 的选项
+type RemoveOptions struct {
+	// Force 删除有未保存工作的 worktree（删除前备份到回收站），
+	// 并允许删除未合并的分支
+	Force bool
+	// DeleteBranch 删除 worktree 后删除本地分支
+	DeleteBranch bool
+	// DeleteRemote 同时删除分支的上游远程分支
+	DeleteRemote bool
+	// Base 判断分支是否已合并时使用的基准分支，默认为仓库默认分支
+	Base string
+}
+
+// RemoveResult 删除 worktree 的结果
+type RemoveResult struct {
+	Worktree Worktree
+	// Trash 是未保存工作的备份，没有需要备份的内容时为 nil
+	Trash *TrashEntry
+	// BranchDeleted 表示本地分支已删除
+	BranchDeleted bool
+	// RemoteBranch 是已删除的远程分支（remote/branch），未删除时为空
+	RemoteBranch string
+	// Warnings 是不影响 worktree 删除结果的问题，例如分支未合并而保留（*BranchNotMergedError）
+	Warnings []error
+}
+
+// PruneOptions 清理 worktree 的选项
+type PruneOptions struct {
+	// DryRun 只返回可清理的 worktree，不实际清理
+	DryRun bool
+}
+
+// Status 表示 worktree 的详细状态
+type Status struct {
+	Worktree Worktree
+	// Changes 是删除后会丢失的工作，目录不存在时为 nil
+	Changes *Changes
+	// Upstream 是当前分支的上游分支，没有时为空
+	Upstream string
+	Ahead    int
+	Behind   int
+}
+
+// List 获取所有 worktree
+func (c *Client) List(ctx context.Context) ([]Worktree, error) {
+	repo, err := c.open(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	worktrees, err := repo.GetWorktrees()
+	if err != nil {
+		return nil, i18n.Errorf("获取 worktree 列表失败: %w", err)
+	}
+
+	return worktrees, nil
+}
+
+// Find 按分支名、路径或部分匹配查找 worktree，target 为空时返回当前所在的 worktree
+func (c *Client) Find(ctx context.Context, target string) (*Worktree, error) {
+	repo, err := c.open(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.find(repo, target)
+}
+
+// find 在已打开的仓库中查找 worktree，target 为空时返回当前所在的 worktree
+func (c *Client) find(repo *git.Repository, target string) (*Worktree, error) {
+	worktrees, err := repo.GetWorktrees()
+	if err != nil {
+		return nil, i18n.Errorf("获取 worktree 列表失败: %w", err)
+	}
+
+	return c.findIn(repo, worktrees, target)
+}
+
+// findIn 在 worktree 列表中查找 worktree，target 为空时返回当前所在的 worktree
+func (c *Client) findIn(repo *git.Repository, worktrees []Worktree, target string) (*Worktree, error) {
+	if target == "" {
+		for i, wt := range worktrees {
+			if wt.Path == repo.Worktree {
+				return &worktrees[i], nil
+			}
+		}
+		return nil, i18n.Errorf("%w: 当前目录不在 worktree 中", ErrNotFound)
+	}
+
+	path, err := c.abs(target)
+	if err != nil {
+		return nil, err
+	}
+
+	return match(worktrees, target, path)
+}
+
+// Match 按分支名、路径或部分匹配在列表中查找 worktree，相对路径相对于当前目录
+//
+// 没有匹配时返回 ErrNotFound；部分匹配到多个 worktree 时返回 *AmbiguousTargetError。
+func Match(worktrees []Worktree, target string) (*Worktree, error) {
+	path, err := filepath.Abs(target)
+	if err != nil {
+		path = target
+	}
+	return match(worktrees, target, path)
+}
+
+// match 依次按分支名、绝对路径和部分匹配查找 worktree
+func match(worktrees []Worktree, target, path string) (*Worktree, error) {
+	// 首先尝试按分支名匹配
+	for i, wt := range worktrees {
+		if wt.Branch == target {
+			return &worktrees[i], nil
+		}
+	}
+
+	// 尝试作为路径匹配
+	for i, wt := range worktrees {
+		if wt.Path == path {
+			return &worktrees[i], nil
+		}
+	}
+
+	// 最后尝试部分匹配，匹配到多个时要求用户给出更精确的名称
+	var found []int
+	for i, wt := range worktrees {
+		if strings.Contains(wt.Path, target) || strings.Contains(wt.Branch, target) {
+			found = append(found, i)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, target)
+	case 1:
+		return &worktrees[found[0]], nil
+	}
+
+	ambiguous := &AmbiguousTargetError{Target: target}
+	for _, i := range found {
+		name := worktrees[i].Branch
+		if name == "" {
+			name = worktrees[i].Path
+		}
+		ambiguous.Matches = append(ambiguous.Matches, name)
+	}
+	return nil, ambiguous
+}
+
+// Create 创建 worktree，分支不存在时自动创建；Detach 时以分离 HEAD 检出 Base
+func (c *Client) Create(ctx context.Context, opts CreateOptions) (*CreateResult, error) {
+	switch {
+	case opts.Orphan && (opts.Detach || len(opts.Sparse) > 0):
+		return nil, i18n.Errorf("孤儿分支不能与分离 HEAD 或稀疏检出同时使用")
+	case opts.Detach && opts.Branch != "":
+		return nil, i18n.Errorf("分离 HEAD 检出时不能指定分支")
+	case opts.Detach && opts.Base == "":
+		opts.Base = "HEAD"
+	case !opts.Detach && opts.Branch == "":
+		return nil, i18n.Errorf("未指定分支")
+	}
+
+	repo, err := c.open(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name := opts.Branch
+	if opts.Detach {
+		name = strings.ReplaceAll(opts.Base, "/", "-")
+	}
+
+	path := opts.Path
+	switch {
+	case path != "":
+		path, err = c.abs(path)
+	case repo.LayoutRoot() != "":
+		path = filepath.Join(repo.LayoutRoot(), name)
+	default:
+		path, err = c.abs(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(path); err == nil && !opts.Force {
+		return nil, fmt.Errorf("%w: %s", ErrPathExists, path)
+	}
+
+	seed := opts.Seed
+	if seed != "" {
+		if seed, err = c.abs(seed); err != nil {
+			return nil, err
+		}
+		if info, err := os.Stat(seed); err != nil || !info.IsDir() {
+			return nil, i18n.Errorf("初始文件目录不存在: %s", seed)
+		}
+	}
+
+	branchExists := true
+	if !opts.Detach && !opts.Orphan {
+		branchExists, err = repo.BranchExists(opts.Branch)
+		if err != nil {
+			return nil, i18n.Errorf("检查分支失败: %w", err)
+		}
+	}
+
+	worktree, err := repo.CreateWorktree(git.CreateWorktreeOptions{
+		Branch:       opts.Branch,
+		Path:         path,
+		BaseBranch:   opts.Base,
+		CreateBranch: !branchExists,
+		Detach:       opts.Detach,
+		Force:        opts.Force,
+		Sparse:       opts.Sparse,
+		Orphan:       opts.Orphan,
+		Seed:         seed,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if opts.Orphan {
+		branchExists = false
+	}
+
+	result := &CreateResult{
+		Worktree: Worktree{
+			Path:       worktree.Path,
+			Branch:     worktree.Branch,
+			Sparse:     len(opts.Sparse) > 0,
+			SparseDirs: opts.Sparse,
+			IsScratch:  opts.Scratch,
+		},
+		NewBranch: !branchExists,
+	}
+	if opts.Detach {
+		result.Worktree.Describe = repo.Describe(worktree.Path)
+	}
+
+	if opts.Scratch {
+		if err := repo.MarkScratch(worktree.Path); err != nil {
+			return result, err
+		}
+	}
+
+	meta := opts.Metadata
+	meta.Tags = updateTags(meta.Tags, nil, nil)
+	meta.CreatedAt = time.Now()
+	if err := repo.WriteMetadata(worktree.Path, meta); err != nil {
+		return result, err
+	}
+	result.Worktree.Metadata = meta
+	result.Worktree.CreatedAt = meta.CreatedAt
+
+	return result, nil
+}
+
+// Status 获取 worktree 的详细状态
+func (c *Client) Status(ctx context.Context, target string) (*Status, error) {
+	repo, err := c.open(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wt, err := c.find(repo, target)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &Status{Worktree: *wt}
+	if _, err := os.Stat(wt.Path); os.IsNotExist(err) {
+		return status, nil
+	}
+
+	status.Changes, err = repo.InspectWorktree(wt.Path, wt.Branch)
+	if err != nil {
+		return nil, i18n.Errorf("检查 worktree %s 失败: %w", wt.Path, err)
+	}
+
+	upstream, err := repo.Upstream(wt.Path)
+	if err != nil {
+		return nil, err
+	}
+	if upstream != "" {
+		status.Upstream = upstream
+		status.Ahead, status.Behind, err = repo.AheadBehind(wt.Path, upstream)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return status, nil
+}
+
+// Remove 删除 worktree
+//
+// 有未保存的工作时返回 *UnsavedWorkError，除非设置了 Force：
+// 此时会先把未保存的工作备份到回收站再删除。
+func (c *Client) Remove(ctx context.Context, target string, opts RemoveOptions) (*RemoveResult, error) {
+	repo, err := c.open(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wt, err := c.find(repo, target)
+	if err != nil {
+		return nil, err
+	}
+	if wt.IsMain {
+		return nil, fmt.Errorf("%w: %s", ErrMainWorktree, wt.Path)
+	}
+
+	if wt.IsLocked {
+		return nil, &LockedError{Path: wt.Path, Reason: wt.LockReason}
+	}
+
+	result := &RemoveResult{Worktree: *wt}
+
+	var changes *Changes
+	if _, err := os.Stat(wt.Path); err == nil {
+		changes, err = repo.InspectWorktree(wt.Path, wt.Branch)
+		if err != nil {
+			return nil, i18n.Errorf("检查 worktree %s 失败: %w", wt.Path, err)
+		}
+	}
+
+	if changes != nil && changes.HasUnsavedWork() {
+		if !opts.Force {
+			return nil, &UnsavedWorkError{Worktree: *wt, Changes: changes}
+		}
+
+		result.Trash, err = repo.MoveToTrash(*wt, changes)
+		if err != nil {
+			return nil, i18n.Errorf("备份未保存的工作失败，已取消删除: %w", err)
+		}
+	}
+
+	if err := repo.RemoveWorktree(wt.Path, opts.Force); err != nil {
+		if !opts.Force || errors.Is(err, ErrLocked) {
+			return nil, err
+		}
+
+		// 强制删除，未保存的工作已备份，手动删除目录
+		if err := os.RemoveAll(wt.Path); err != nil {
+			return nil, i18n.Errorf("强制删除目录失败: %w", err)
+		}
+
+		// 清理 git worktree 记录
+		if err := repo.PruneWorktrees(); err != nil {
+			c.warnf(i18n.T("清理 worktree 记录失败: %v"), err)
+		}
+	}
+
+	if opts.DeleteBranch && wt.Branch != "" {
+		if err := c.deleteBranch(repo, wt.Branch, opts, result); err != nil {
+			result.Warnings = append(result.Warnings, err)
+		}
+	}
+
+	return result, nil
+}
+
+// deleteBranch 删除 worktree 对应的分支
+//
+// 分支已合并到基准分支或上游分支时直接删除本地分支；未合并时需要 Force。
+// 删除远程分支要求分支已合并到基准分支：只合并到自己的上游说明提交只在远程分支上，
+// 删除后就会丢失，因此同样需要 Force。
+func (c *Client) deleteBranch(repo *git.Repository, branch string, opts RemoveOptions, result *RemoveResult) error {
+	base := opts.Base
+	if base == "" {
+		base, _ = repo.DefaultBranch()
+	}
+
+	mergedInto, err := repo.BranchMergedInto(branch, base)
+	if err != nil {
+		return i18n.Errorf("检查分支 %s 是否已合并失败: %w", branch, err)
+	}
+
+	if mergedInto == "" && !opts.Force {
+		return &BranchNotMergedError{Branch: branch, Base: base}
+	}
+
+	// 删除前记录上游，本地分支删除后就无法再查询
+	remote, remoteBranch := repo.BranchUpstream(branch)
+
+	// 是否已合并由 BranchMergedInto 判断（包括合并到基准分支的情况），
+	// git branch -d 只检查 HEAD 和上游，因此这里使用 -D
+	if err := repo.DeleteBranch(branch, true); err != nil {
+		return err
+	}
+	result.BranchDeleted = true
+
+	if !opts.DeleteRemote {
+		return nil
+	}
+
+	if remote == "" {
+		return i18n.Errorf("分支 %s 没有上游分支，跳过删除远程分支", branch)
+	}
+
+	if base == "" || mergedInto != base {
+		if !opts.Force {
+			return &BranchNotMergedError{Branch: branch, Base: base, Remote: remote + "/" + remoteBranch}
+		}
+	}
+
+	if err := repo.DeleteRemoteBranch(remote, remoteBranch); err != nil {
+		return err
+	}
+	result.RemoteBranch = remote + "/" + remoteBranch
+
+	return nil
+}
+
+// Prune 清理目录已不存在的 worktree 记录，返回被清理的 worktree
+func (c *Client) Prune(ctx context.Context, opts PruneOptions) ([]Worktree, error) {
+	repo, err := c.open(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prunable, err := repo.PrunableWorktrees()
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.DryRun || len(prunable) == 0 {
+		return prunable, nil
+	}
+
+	if err := repo.PruneWorktrees(); err != nil {
+		return nil, err
+	}
+
+	return prunable, nil
+}
+
+// Lock 锁定 worktree，防止被删除或清理
+func (c *Client) Lock(ctx context.Context, target, reason string) (*Worktree, error) {
+	repo, err := c.open(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wt, err := c.find(repo, target)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repo.LockWorktree(wt.Path, reason); err != nil {
+		return nil, err
+	}
+
+	wt.IsLocked = true
+	wt.LockReason = reason
+	return wt, nil
+}
+
+// Unlock 解锁 worktree
+func (c *Client) Unlock(ctx context.Context, target string) (*Worktree, error) {
+	repo, err := c.open(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wt, err := c.find(repo, target)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repo.UnlockWorktree(wt.Path); err != nil {
+		return nil, err
+	}
+
+	wt.IsLocked = false
+	wt.LockReason = ""
+	return wt, nil
+}